@@ -0,0 +1,71 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+package TOML
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// return the value identified by the dotted path (e.g. "client.address.city",
+// "hobbies[1]", "regions.asia.zones[0]") of the given config Struct (value or
+// pointer) as type T; Struct(s) of any depth, maps, slices and arrays are
+// walked (check common.GetValueByPath). Numeric values are converted to a
+// numeric T (e.g. an int field read as float64) unless they overflow T or
+// are non integral floats for an integer T; any other type mismatch is
+// an error. A missing path returns a *common.PathError wrapping
+// common.ErrPathNotFound.
+func Get[T any](configObject interface{}, path string, strategy ...common.KeyNamingStrategy) (T, error) {
+	var result T
+	val, err := common.GetValueByPath(configObject, path, strategy...)
+	if err != nil {
+		return result, err
+	}
+	targetType := reflect.TypeOf(&result).Elem()
+	// map entries (map[string]interface{}) and pointers hold the real value
+	for (val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr) && !val.IsNil() &&
+		!val.Type().AssignableTo(targetType) {
+		val = val.Elem()
+	}
+
+	if val.Type().AssignableTo(targetType) {
+		reflect.ValueOf(&result).Elem().Set(val)
+		return result, nil
+	}
+	if common.IsNumericKind(val.Kind()) && common.IsNumericKind(targetType.Kind()) {
+		numVal, err := common.ConvertNumericValue(val, targetType, path)
+		if err != nil {
+			return result, err
+		}
+		reflect.ValueOf(&result).Elem().Set(numVal)
+		return result, nil
+	}
+	return result, &common.PathError{ Path: path,
+		Reason: fmt.Sprintf("cannot convert [%v] to [%v]", val.Type(), targetType) }
+}
+
+// set the value identified by the dotted path (check Get) of the given
+// config Struct pointer; string values are coerced into the field's type
+// with the same rules as loading (e.g. Set(&config, "author.birthday",
+// "1990-02-28")). Returns a *common.PathError if the path could not be
+// resolved or the value could not be coerced.
+func Set(ptrConfigObject interface{}, path string, value interface{}, strategy ...common.KeyNamingStrategy) error {
+	return common.SetValueByPath(ptrConfigObject, path, value, strategy...)
+}
@@ -0,0 +1,224 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+package TOML
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"runtime"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// decode the toml contents into the given Struct pointer (same as
+// NewDecoder(bytes.NewReader(data)).Decode(ptrConfigObject)).
+func Unmarshal(data []byte, ptrConfigObject interface{}) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(ptrConfigObject)
+}
+
+// encode the given Struct (value or pointer) into toml contents (same as
+// NewEncoder(&buffer).Encode(configObject)).
+func Marshal(configObject interface{}) ([]byte, error) {
+	var bBuffer bytes.Buffer
+	if err := NewEncoder(&bBuffer).Encode(configObject); err != nil {
+		return nil, err
+	}
+	return bBuffer.Bytes(), nil
+}
+
+/* ------------------------------------ */
+/*	Decoder								*/
+/* ------------------------------------ */
+
+// Decoder reads and decodes toml contents from an io.Reader. The options
+// (exported fields) could be set before calling Decode.
+type Decoder struct {
+	reader io.Reader
+
+	// the Struct's type to decode into; by default the type the pointer
+	// given to Decode points to.
+	StructType reflect.Type
+
+	// strategy to derive the keys of the fields without a toml Tag
+	// (check common.KeyNamingStrategy); by default such fields are ignored.
+	NamingStrategy common.KeyNamingStrategy
+
+	// optional callback invoked for every deprecated key (alias:"..." or
+	// deprecated:"..." Tags) found during decoding.
+	OnDeprecatedKey func(warning common.DeprecationWarning)
+
+	// the name of the source (e.g. a filename) reported in the
+	// DeprecationWarning(s); optional.
+	Name string
+}
+
+// create a new Decoder reading from the given io.Reader.
+func NewDecoder(reader io.Reader) *Decoder {
+	return &Decoder{ reader: reader }
+}
+
+// read the toml contents and populate the given Struct pointer; the
+// BeforeLoad / AfterLoad hooks and the validations are run as in
+// TOMLConfigImpl.Load.
+func (d *Decoder) Decode(ptrConfigObject interface{}) error {
+	_, err := d.DecodeWithMetaData(ptrConfigObject)
+	return err
+}
+
+// same as Decode; additionally returns the meta data of the decoding
+// (check TOMLConfigImpl.LoadWithMetaData).
+func (d *Decoder) DecodeWithMetaData(ptrConfigObject interface{}) (metaData common.DecodeMetaData, err error) {
+	metaData = common.NewDecodeMetaData()
+	// defer
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(runtime.Error); ok {
+				// runtime error, check if anything could be helped to continue the program
+				panic(r)
+			}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	if !common.IsNonNilPointer(ptrConfigObject) {
+		return metaData, errors.New("a non nil pointer to the config object is required")
+	}
+	structType := d.StructType
+	if structType == nil {
+		structType = reflect.TypeOf(ptrConfigObject).Elem()
+	}
+	if d.reader == nil {
+		return metaData, errors.New("the reader is nil")
+	}
+	bBytes, err := ioutil.ReadAll(d.reader)
+	if err != nil {
+		return metaData, err
+	}
+
+	if err := common.InvokeBeforeLoadHooks(ptrConfigObject); err != nil {
+		return metaData, err
+	}
+	// build the object based on the given Type plus populate the contents loaded into bBytes
+	lines := common.GetLinesFromByteArrayContent(bBytes)
+	ok, err := common.PopulateFieldValuesByStrategy(lines, common.ConfigTypeTOML, ptrConfigObject, structType, &metaData, d.NamingStrategy)
+	if !ok && err != nil {
+		return metaData, err
+	}
+	d.notifyDeprecatedKeys(&metaData)
+	if err := common.InvokeAfterLoadHooks(ptrConfigObject); err != nil {
+		return metaData, err
+	}
+	// field level validation (validate Tag) after population
+	if err := common.ValidateFieldValuesByStrategy(ptrConfigObject, &metaData, d.NamingStrategy); err != nil {
+		return metaData, err
+	}
+	return metaData, nil
+}
+
+// fill in the source name of the deprecated keys found and invoke the
+// OnDeprecatedKey callback (if any) in the order of the source lines.
+func (d *Decoder) notifyDeprecatedKeys(metaData *common.DecodeMetaData) {
+	for idx := range metaData.Deprecated {
+		metaData.Deprecated[idx].File = d.Name
+		if d.OnDeprecatedKey != nil {
+			d.OnDeprecatedKey(metaData.Deprecated[idx])
+		}
+	}
+}
+
+/* ------------------------------------ */
+/*	Encoder								*/
+/* ------------------------------------ */
+
+// Encoder encodes Struct(s) into toml contents and writes them to an
+// io.Writer. The options (exported fields) could be set before calling
+// Encode.
+type Encoder struct {
+	writer io.Writer
+
+	// strategy to derive the keys of the fields without a toml Tag
+	// (check common.KeyNamingStrategy); by default such fields are ignored.
+	NamingStrategy common.KeyNamingStrategy
+
+	// write the keys sorted alphabetically instead of the Struct field
+	// order (scalars are always written before the tables).
+	SortKeys bool
+
+	// write the child Structs as dotted keys (e.g. client.address.city = "Seoul")
+	// instead of [table] sections.
+	DottedKeys bool
+}
+
+// create a new Encoder writing to the given io.Writer.
+func NewEncoder(writer io.Writer) *Encoder {
+	return &Encoder{ writer: writer }
+}
+
+// encode the given Struct (value or pointer) and write the toml contents;
+// the BeforeSave / AfterSave hooks and the validations are run as in
+// TOMLConfigImpl.Save. Nothing is written if the validation fails.
+func (e *Encoder) Encode(configObject interface{}) error {
+	if e.writer == nil {
+		return errors.New("the writer is nil")
+	}
+	configObjectPtr, err := e.prepareConfigObject(configObject)
+	if err != nil {
+		return err
+	}
+	cfgLines, _ := e.translateToString(reflect.ValueOf(configObjectPtr).Elem().Interface())
+	if _, err = io.WriteString(e.writer, cfgLines); err != nil {
+		return err
+	}
+	return common.InvokeAfterSaveHooks(configObjectPtr)
+}
+
+// invoke the BeforeSave hooks and validate the values (validate Tag plus
+// the IConfigValidator hook); returns a pointer to the config object (a
+// copy if a Struct value is given) for the remaining steps.
+func (e *Encoder) prepareConfigObject(configObject interface{}) (interface{}, error) {
+	if configObject == nil ||
+		(reflect.ValueOf(configObject).Kind() == reflect.Ptr && reflect.ValueOf(configObject).IsNil()) {
+		return nil, errors.New("the config object to persist is nil")
+	}
+	// an addressable copy / reference for the hooks to work on
+	configObjectPtr := getStructPointer(configObject)
+	if err := common.InvokeBeforeSaveHooks(configObjectPtr); err != nil {
+		return nil, err
+	}
+	if err := common.ValidateFieldValuesByStrategy(configObjectPtr, nil, e.NamingStrategy); err != nil {
+		return nil, err
+	}
+	return configObjectPtr, nil
+}
+
+// translate the Struct value into toml lines; false is returned if the
+// Struct has no keys to write.
+func (e *Encoder) translateToString(configObject interface{}) (string, bool) {
+	// collect the key values of the available config tags in Struct field
+	// order (toml:"-" fields are skipped, empty values are omitted only if the
+	// "omitempty" option is set, inline Struct(s) are merged in)
+	keyValues := common.GetTomlKeyValuesByStrategy(configObject, e.NamingStrategy, e.SortKeys)
+	if e.DottedKeys {
+		return translateKeyValuesToDottedString(keyValues), len(keyValues) > 0
+	}
+	return translateKeyValuesToTableString(keyValues), len(keyValues) > 0
+}
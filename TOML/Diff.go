@@ -0,0 +1,251 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package TOML
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// the type of a Change.
+type ChangeType int
+
+const (
+	// the key exists in the second config only
+	ChangeAdded ChangeType = iota
+	// the key exists in the first config only
+	ChangeRemoved
+	// the key's value differs
+	ChangeModified
+)
+
+// return the name of the change type (e.g. "modified").
+func (c ChangeType) String() string {
+	switch c {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(c))
+}
+
+// a changed key value between 2 configs (check Diff and DiffFiles).
+type Change struct {
+	// the full key e.g. "author.age"
+	Key string
+	Type ChangeType
+	// the value in the first config (nil if added)
+	OldValue interface{}
+	// the value in the second config (nil if removed)
+	NewValue interface{}
+}
+
+// return a summary of the change e.g. "author.age: 25 -> 26",
+// "hobbies: +guitar, -reading", "broker.id removed" or "tags added: [a]".
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("%v added: %v", c.Key, formatChangeSummaryValue(c.NewValue))
+	case ChangeRemoved:
+		return fmt.Sprintf("%v removed", c.Key)
+	}
+	if elements := getArrayElementChanges(c.OldValue, c.NewValue); len(elements) > 0 {
+		return fmt.Sprintf("%v: %v", c.Key, strings.Join(elements, ", "))
+	}
+	return fmt.Sprintf("%v: %v -> %v", c.Key, formatChangeSummaryValue(c.OldValue), formatChangeSummaryValue(c.NewValue))
+}
+
+// return the changed key values between the 2 config Structs (values or
+// pointers; nil => no keys); the keys of "b" come first (Struct field
+// order) followed by the keys removed from "a". Values are compared by
+// their toml representation and reported as the fields' values. No hooks
+// are invoked hence the Structs are left untouched.
+func Diff(a, b interface{}, strategy ...common.KeyNamingStrategy) []Change {
+	keyStrategy := common.NamingNone
+	if len(strategy) > 0 {
+		keyStrategy = strategy[0]
+	}
+	return diffEntries(getStructDiffEntries(a, keyStrategy), getStructDiffEntries(b, keyStrategy))
+}
+
+// return the changed key values between the 2 toml files at the document
+// level (no Struct involved; check ParseFile); the keys of the second file
+// come first (declaration order) followed by the keys removed from the
+// first file. Values are reported as the Tree's values (e.g. int64).
+func DiffFiles(pathA, pathB string) ([]Change, error) {
+	treeA, err := ParseFile(pathA)
+	if err != nil {
+		return nil, err
+	}
+	treeB, err := ParseFile(pathB)
+	if err != nil {
+		return nil, err
+	}
+	return diffEntries(getTreeDiffEntries(treeA), getTreeDiffEntries(treeB)), nil
+}
+
+// render the changes as a unified text diff of toml lines e.g.
+//
+//	--- a.toml
+//	+++ b.toml
+//	-author.age = 25
+//	+author.age = 26
+func FormatUnifiedDiff(changes []Change, nameA, nameB string) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("--- %v\n+++ %v\n", nameA, nameB))
+	for _, change := range changes {
+		if change.Type != ChangeAdded {
+			buffer.WriteString(fmt.Sprintf("-%v = %v\n", change.Key, formatChangeValue(change.OldValue)))
+		}
+		if change.Type != ChangeRemoved {
+			buffer.WriteString(fmt.Sprintf("+%v = %v\n", change.Key, formatChangeValue(change.NewValue)))
+		}
+	}	// end -- for (changes)
+	return buffer.String()
+}
+
+/* ------------------- */
+/*	diff entries	   */
+/* ------------------- */
+
+// a key value to diff; "text" is the toml representation to compare.
+type diffEntry struct {
+	key string
+	text string
+	value interface{}
+}
+
+func diffEntries(oldEntries, newEntries []diffEntry) []Change {
+	oldTexts := make(map[string]diffEntry)
+	for _, entry := range oldEntries {
+		oldTexts[entry.key] = entry
+	}
+	changes := []Change{}
+	newKeys := make(map[string]bool)
+	for _, entry := range newEntries {
+		newKeys[entry.key] = true
+		oldEntry, ok := oldTexts[entry.key]
+		if !ok {
+			changes = append(changes, Change{ Key: entry.key, Type: ChangeAdded, NewValue: entry.value })
+		} else if oldEntry.text != entry.text {
+			changes = append(changes, Change{ Key: entry.key, Type: ChangeModified, OldValue: oldEntry.value, NewValue: entry.value })
+		}
+	}	// end -- for (new entries)
+	for _, entry := range oldEntries {
+		if !newKeys[entry.key] {
+			changes = append(changes, Change{ Key: entry.key, Type: ChangeRemoved, OldValue: entry.value })
+		}
+	}	// end -- for (removed entries)
+	return changes
+}
+
+// the key values of the Struct (same keys as Save); the values are the
+// fields' values.
+func getStructDiffEntries(object interface{}, strategy common.KeyNamingStrategy) []diffEntry {
+	entries := []diffEntry{}
+	if object == nil || (reflect.ValueOf(object).Kind() == reflect.Ptr && reflect.ValueOf(object).IsNil()) {
+		return entries
+	}
+	for _, kv := range flattenTomlKeyValues(common.GetTomlKeyValuesByStrategy(object, strategy, false)) {
+		value := getFieldValueByKey(object, kv.Key, strategy)
+		if value == nil {
+			value = kv.Value
+		}
+		entries = append(entries, diffEntry{ key: kv.Key, text: formatScalarValue(kv.Value), value: value })
+	}	// end -- for (key values)
+	return entries
+}
+
+// the key values of the Tree (declaration order).
+func getTreeDiffEntries(tree *Tree) []diffEntry {
+	entries := []diffEntry{}
+	for _, key := range tree.Keys() {
+		value := tree.Get(key)
+		entries = append(entries, diffEntry{ key: key, text: formatNodeValue(value), value: value })
+	}	// end -- for (keys)
+	return entries
+}
+
+// return the (Go) value of the key; nil if the key could not be resolved.
+func getFieldValueByKey(object interface{}, key string, strategy common.KeyNamingStrategy) interface{} {
+	val, err := common.GetValueByPath(object, key, strategy)
+	if err != nil || !val.CanInterface() {
+		return nil
+	}
+	return val.Interface()
+}
+
+/* ------------------- */
+/*	formatting		   */
+/* ------------------- */
+
+// format the value into its toml representation (Struct and Tree values alike).
+func formatChangeValue(value interface{}) string {
+	node, err := newNodeByValue(reflect.ValueOf(value))
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return formatNodeValue(node.Value)
+}
+
+// format the value for a summary; strings are not quoted (unless empty).
+func formatChangeSummaryValue(value interface{}) string {
+	if sValue, ok := value.(string); ok && len(sValue) > 0 {
+		return sValue
+	}
+	return formatChangeValue(value)
+}
+
+// return the added (+) and removed (-) elements of 2 arrays e.g.
+// ["+guitar", "-reading"]; empty if the values are not arrays or only the
+// order of the elements changed.
+func getArrayElementChanges(oldValue, newValue interface{}) []string {
+	oldNode, oErr := newNodeByValue(reflect.ValueOf(oldValue))
+	newNode, nErr := newNodeByValue(reflect.ValueOf(newValue))
+	if oErr != nil || nErr != nil || oldNode.Type != NodeArray || newNode.Type != NodeArray {
+		return nil
+	}
+	// count the old elements; the matched ones are not changed
+	oldCounts := make(map[string]int)
+	for _, element := range oldNode.Value.([]interface{}) {
+		oldCounts[formatNodeValue(element)]++
+	}
+	changes := []string{}
+	for _, element := range newNode.Value.([]interface{}) {
+		text := formatNodeValue(element)
+		if oldCounts[text] > 0 {
+			oldCounts[text]--
+		} else {
+			changes = append(changes, "+" + formatChangeSummaryValue(element))
+		}
+	}	// end -- for (new elements)
+	for _, element := range oldNode.Value.([]interface{}) {
+		text := formatNodeValue(element)
+		if oldCounts[text] > 0 {
+			oldCounts[text]--
+			changes = append(changes, "-" + formatChangeSummaryValue(element))
+		}
+	}	// end -- for (removed elements)
+	return changes
+}
@@ -0,0 +1,338 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Document contains the comment and format preserving document model of a
+// toml file.
+package TOML
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// struct wrapping up the lines of a toml file; comments, blank lines,
+// whitespace and the key order are kept as-is, hence only the lines of the
+// keys updated are rewritten when the document is written back.
+type Document struct {
+	lines []*documentLine
+}
+
+// struct describing a line of the document.
+type documentLine struct {
+	// the line's text (without the line break)
+	raw string
+	// the [table] header's key (for header lines only)
+	table string
+	// the full key (table + key) of a key / value line
+	key string
+	// the [table] a key / value line belongs to
+	section string
+
+	// a key / value line is split into 3 parts => raw = prefix + value + suffix
+	// prefix = indentation + key + " = "
+	prefix string
+	// the value's toml representation (e.g. "Seoul" with the quotes)
+	value string
+	// trailing whitespace plus the inline comment (if any)
+	suffix string
+}
+
+// create a Document based on the contents of a toml file.
+func ParseDocument(data []byte) *Document {
+	doc := &Document{ lines: []*documentLine{} }
+	table := ""
+
+	for _, raw := range strings.Split(string(data), "\n") {
+		line := parseDocumentLine(raw, table)
+		if len(line.table) > 0 {
+			table = line.table
+		}
+		doc.lines = append(doc.lines, line)
+	}	// end -- for (lines)
+	return doc
+}
+
+// load the toml file into a Document.
+func LoadDocument(filenameOrPath string) (*Document, error) {
+	bBytes, err := common.LoadFile(filenameOrPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocument(bBytes), nil
+}
+
+// parse a line; "table" is the [table] header the line belongs to.
+func parseDocumentLine(raw, table string) *documentLine {
+	line := &documentLine{ raw: raw }
+	trimmed := strings.TrimSpace(raw)
+
+	if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+		return line
+	}
+	if strings.HasPrefix(trimmed, "[") {
+		if end := strings.Index(trimmed, "]"); end > 0 && !strings.Contains(trimmed[:end], "=") {
+			line.table = strings.TrimSpace(strings.Trim(trimmed[:end], "[]"))
+			return line
+		}
+	}
+	eqIdx := strings.Index(raw, "=")
+	if eqIdx <= 0 {
+		return line
+	}
+	valueStart := eqIdx + 1
+	for valueStart < len(raw) && (raw[valueStart] == ' ' || raw[valueStart] == '\t') {
+		valueStart++
+	}
+	valueEnd := common.GetInlineCommentIndex(raw, valueStart)
+	value := strings.TrimRight(raw[valueStart:valueEnd], " \t\r")
+
+	line.key = getDocumentKey(table, strings.TrimSpace(raw[:eqIdx]))
+	line.section = table
+	line.prefix = raw[:valueStart]
+	line.value = value
+	line.suffix = raw[valueStart+len(value):]
+	return line
+}
+
+// return the full key of a key declared under the given table.
+func getDocumentKey(table, key string) string {
+	if len(table) > 0 {
+		return table + "." + key
+	}
+	return key
+}
+
+// return the contents of the document; unchanged lines are returned as-is.
+func (d *Document) Bytes() []byte {
+	var bBuffer bytes.Buffer
+	for idx, line := range d.lines {
+		if idx > 0 {
+			bBuffer.WriteString("\n")
+		}
+		bBuffer.WriteString(line.raw)
+	}
+	return bBuffer.Bytes()
+}
+
+// return the full keys declared in the document (in line order).
+func (d *Document) Keys() []string {
+	keys := []string{}
+	for _, line := range d.lines {
+		if len(line.key) > 0 {
+			keys = append(keys, line.key)
+		}
+	}
+	return keys
+}
+
+// check if the given full key (e.g. "client.address.city") is declared.
+func (d *Document) HasKey(key string) bool {
+	return d.getKeyLineIndex(key) != -1
+}
+
+// return the toml representation of the key's value (e.g. "Seoul" with
+// the quotes); false is returned if the key is not declared.
+func (d *Document) GetValue(key string) (string, bool) {
+	if idx := d.getKeyLineIndex(key); idx != -1 {
+		return d.lines[idx].value, true
+	}
+	return "", false
+}
+
+// set the toml representation of the key's value (e.g. "\"Seoul\""); only
+// the value part of an existing line is replaced (indentation and inline
+// comments are kept). A missing key is added to the section of its table.
+func (d *Document) SetValue(key, value string) {
+	if idx := d.getKeyLineIndex(key); idx != -1 {
+		line := d.lines[idx]
+		line.value = value
+		line.raw = line.prefix + line.value + line.suffix
+		return
+	}
+	d.insertKey(key, value)
+}
+
+// remove the line of the given key; false is returned if the key is not declared.
+func (d *Document) DeleteKey(key string) bool {
+	idx := d.getKeyLineIndex(key)
+	if idx == -1 {
+		return false
+	}
+	d.lines = append(d.lines[:idx], d.lines[idx+1:]...)
+	return true
+}
+
+// rename the key; the line is kept in place if the new key belongs to the
+// same [table], else the key is moved to the new key's table (the inline
+// comment is kept in both cases). False is returned if the old key is not declared or the new
+// key is declared already.
+func (d *Document) RenameKey(oldKey, newKey string) bool {
+	idx := d.getKeyLineIndex(oldKey)
+	if idx == -1 || d.HasKey(newKey) {
+		return false
+	}
+	line := d.lines[idx]
+	if strings.Compare(d.getKeyTable(newKey), line.section) == 0 {
+		localKey := newKey
+		if len(line.section) > 0 {
+			localKey = strings.TrimPrefix(newKey, line.section + ".")
+		}
+		keyStart := len(line.prefix) - len(strings.TrimLeft(line.prefix, " \t"))
+		oldLocalKey := strings.TrimSpace(line.prefix[keyStart:strings.Index(line.prefix, "=")])
+
+		line.prefix = line.prefix[:keyStart] + localKey + line.prefix[keyStart+len(oldLocalKey):]
+		line.key = newKey
+		line.raw = line.prefix + line.value + line.suffix
+		return true
+	}
+	d.DeleteKey(oldKey)
+	d.insertKey(newKey, line.value)
+	if movedLine := d.lines[d.getKeyLineIndex(newKey)]; len(line.suffix) > 0 {
+		movedLine.suffix = line.suffix
+		movedLine.raw = movedLine.prefix + movedLine.value + movedLine.suffix
+	}
+	return true
+}
+
+func (d *Document) getKeyLineIndex(key string) int {
+	for idx, line := range d.lines {
+		if len(line.key) > 0 && strings.Compare(line.key, key) == 0 {
+			return idx
+		}
+	}
+	return -1
+}
+
+// return the longest [table] prefixing the given key; "" => the root section.
+func (d *Document) getKeyTable(key string) string {
+	table := ""
+	for _, line := range d.lines {
+		if len(line.table) > len(table) && strings.HasPrefix(key, line.table + ".") {
+			table = line.table
+		}
+	}
+	return table
+}
+
+// add a new key; the key goes to (in order of preference)
+// 1) the section of the key's table (the longest table prefixing the key),
+// 2) after the last root level dotted key sharing the key's first part,
+// 3) the end of the root level keys (keys without a dot),
+// 4) a new [table] section (the key's table) at the end of the document;
+//    intermediate tables are implicitly declared by TOML.
+func (d *Document) insertKey(key, value string) {
+	// 1) table section
+	bestTable, bestIdx := d.getKeyTable(key), -1
+	for idx, line := range d.lines {
+		if len(bestTable) > 0 && strings.Compare(line.table, bestTable) == 0 {
+			bestIdx = idx
+			break
+		}
+	}
+	if bestIdx != -1 && !strings.Contains(strings.TrimPrefix(key, bestTable + "."), ".") {
+		d.insertLine(d.getSectionInsertIndex(bestIdx), newDocumentLine(bestTable, strings.TrimPrefix(key, bestTable + "."), value))
+		return
+	}
+	rootEnd := d.getRootSectionEnd()
+	dotIdx := strings.Index(key, ".")
+
+	// 2) dotted keys within the root section
+	if dotIdx > 0 {
+		for idx := rootEnd - 1; idx >= 0; idx-- {
+			if strings.HasPrefix(d.lines[idx].key, key[:dotIdx+1]) {
+				d.insertLine(idx + 1, newDocumentLine("", key, value))
+				return
+			}
+		}
+	}
+	// 3) root level keys
+	if dotIdx == -1 {
+		insertIdx := d.getSectionInsertIndex(-1)
+		if insertIdx > rootEnd {
+			insertIdx = rootEnd
+		}
+		d.insertLine(insertIdx, newDocumentLine("", key, value))
+		return
+	}
+	// 4) new table section
+	lastDotIdx := strings.LastIndex(key, ".")
+	table := key[:lastDotIdx]
+	insertIdx := d.getEndIndex()
+	lines := []*documentLine{ {raw: "[" + table + "]", table: table}, newDocumentLine(table, key[lastDotIdx+1:], value) }
+	if insertIdx > 0 && len(strings.TrimSpace(d.lines[insertIdx-1].raw)) > 0 {
+		lines = append([]*documentLine{ {raw: ""} }, lines...)
+	}
+	for i, line := range lines {
+		d.insertLine(insertIdx + i, line)
+	}
+}
+
+// create a key / value line.
+func newDocumentLine(table, localKey, value string) *documentLine {
+	return &documentLine{
+		raw: localKey + " = " + value,
+		key: getDocumentKey(table, localKey),
+		section: table,
+		prefix: localKey + " = ",
+		value: value,
+	}
+}
+
+// return the index right after the last key of the section started at
+// "headerIdx" (-1 => the root section); right after the header if the
+// section has no keys.
+func (d *Document) getSectionInsertIndex(headerIdx int) int {
+	insertIdx := headerIdx + 1
+	for idx := headerIdx + 1; idx < len(d.lines); idx++ {
+		if len(d.lines[idx].table) > 0 {
+			break
+		}
+		if len(d.lines[idx].key) > 0 {
+			insertIdx = idx + 1
+		}
+	}
+	return insertIdx
+}
+
+// return the index of the first [table] header; comments and blank lines
+// right above the header belong to the header.
+func (d *Document) getRootSectionEnd() int {
+	for idx, line := range d.lines {
+		if len(line.table) > 0 {
+			for idx > 0 && len(d.lines[idx-1].key) == 0 && len(d.lines[idx-1].table) == 0 {
+				idx--
+			}
+			return idx
+		}
+	}
+	return d.getEndIndex()
+}
+
+// return the index to append lines (before the trailing line break if any).
+func (d *Document) getEndIndex() int {
+	if len(d.lines) > 0 && len(d.lines[len(d.lines)-1].raw) == 0 {
+		return len(d.lines) - 1
+	}
+	return len(d.lines)
+}
+
+func (d *Document) insertLine(idx int, line *documentLine) {
+	d.lines = append(d.lines, nil)
+	copy(d.lines[idx+1:], d.lines[idx:])
+	d.lines[idx] = line
+}
@@ -0,0 +1,238 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package TOML
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// the error wrapped by Save if the config file was modified since the last
+// Load / Save (TOMLConfigImpl.DetectConflicts; check errors.Is).
+var ErrConfigModified = errors.New("the config file was modified since it was loaded")
+
+// a key changed differently by both sides of a three-way merge.
+type MergeConflict struct {
+	Key string
+	// the values of the base, local and remote versions (nil if absent)
+	BaseValue interface{}
+	LocalValue interface{}
+	RemoteValue interface{}
+}
+
+// return a summary of the conflict e.g. "port: 8080 -> 8443 (local), 9443 (remote)".
+func (c MergeConflict) String() string {
+	return fmt.Sprintf("%v: %v -> %v (local), %v (remote)", c.Key,
+		formatMergeValue(c.BaseValue), formatMergeValue(c.LocalValue), formatMergeValue(c.RemoteValue))
+}
+
+// error returned by TOMLConfigImpl.Merge listing the conflicts per key.
+type MergeConflictError struct {
+	Conflicts []MergeConflict
+}
+
+// return the description of the conflicts.
+func (e *MergeConflictError) Error() string {
+	sConflicts := make([]string, len(e.Conflicts))
+	for idx, conflict := range e.Conflicts {
+		sConflicts[idx] = conflict.String()
+	}
+	return fmt.Sprintf("%v merge conflict(s): %v", len(e.Conflicts), strings.Join(sConflicts, "; "))
+}
+
+// three-way merge of the key values of the local and remote versions
+// based on their common ancestor (base). Per key, a side equal to the base
+// takes the other side's value (added, modified or removed); keys changed
+// the same way by both sides are kept; keys changed differently are
+// conflicts (the merged Tree keeps the local value). Keys removed by both
+// sides are dropped. The keys of the remote version come first
+// (declaration order) followed by the local only keys.
+func MergeTrees(base, local, remote *Tree) (*Tree, []MergeConflict, error) {
+	keys := remote.Keys()
+	for _, key := range local.Keys() {
+		if !remote.Has(key) {
+			keys = append(keys, key)
+		}
+	}	// end -- for (local keys)
+	merged := NewTree()
+	conflicts := []MergeConflict{}
+	for _, key := range keys {
+		baseValue, localValue, remoteValue := base.Get(key), local.Get(key), remote.Get(key)
+		value := localValue
+		switch {
+		case isMergeValueEqual(localValue, remoteValue):
+		case isMergeValueEqual(localValue, baseValue):
+			value = remoteValue
+		case isMergeValueEqual(remoteValue, baseValue):
+		default:
+			conflicts = append(conflicts, MergeConflict{ Key: key,
+				BaseValue: baseValue, LocalValue: localValue, RemoteValue: remoteValue })
+		}
+		if value == nil {
+			continue
+		}
+		if err := merged.Set(key, value); err != nil {
+			return nil, nil, err
+		}
+	}	// end -- for (keys)
+	return merged, conflicts, nil
+}
+
+// three-way merge of the given Struct pointer (local) with the config file
+// (remote) based on the contents of the last Load / Save (base); handy
+// after Save failed with ErrConfigModified. Keys the Struct does not write
+// are treated as unchanged locally. Without conflicts the Struct is
+// replaced by the merged values (hooks and validations are run) and the
+// remote contents become the new base, hence a following Save succeeds
+// with DetectConflicts set. Otherwise the conflicts are returned (wrapped
+// by a *MergeConflictError as well) and the Struct is left untouched.
+func (t *TOMLConfigImpl) Merge(ptrConfigObject interface{}) ([]MergeConflict, error) {
+	if !common.IsNonNilPointer(ptrConfigObject) {
+		return nil, errors.New("a pointer to the config Struct is required")
+	}
+	if t.baseContents == nil || !isSameConfigPath(t.Name, t.basePath) {
+		return nil, fmt.Errorf("[%v] must be loaded before merging", t.Name)
+	}
+	remoteContents, err := common.LoadFile(t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if sha256.Sum256(remoteContents) == t.baseChecksum {
+		// nothing changed remotely
+		return nil, nil
+	}
+	base, err := Parse(t.baseContents)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := Parse(remoteContents)
+	if err != nil {
+		return nil, err
+	}
+	local, err := t.getLocalMergeTree(ptrConfigObject, base)
+	if err != nil {
+		return nil, err
+	}
+	merged, conflicts, err := MergeTrees(base, local, remote)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return conflicts, &MergeConflictError{ Conflicts: conflicts }
+	}
+
+	// decode into a fresh value; the Struct is untouched on failures
+	objType := reflect.TypeOf(ptrConfigObject).Elem()
+	mergedPtr := reflect.New(objType)
+	if err = t.Decode(bytes.NewReader(merged.Bytes()), mergedPtr.Interface()); err != nil {
+		return nil, err
+	}
+	if t.document != nil && strings.Compare(filepath.Clean(t.Name), filepath.Clean(t.documentPath)) == 0 {
+		// the remembered Document (PreserveFormat) reflects the remote contents
+		remotePtr := reflect.New(objType)
+		if err = t.Decode(bytes.NewReader(remoteContents), remotePtr.Interface()); err != nil {
+			return nil, err
+		}
+		t.document = ParseDocument(remoteContents)
+		_, t.documentValues = t.getTomlValuesInString(remotePtr.Interface())
+	}
+	reflect.ValueOf(ptrConfigObject).Elem().Set(mergedPtr.Elem())
+	t.rememberBase(t.Name, remoteContents)
+	return nil, nil
+}
+
+// the Tree of the Struct's values (no hooks invoked); keys of the base the
+// Struct does not write are kept as-is (unchanged). The encoder writes the
+// time.Time values quoted, hence they are replaced by the Struct's values
+// to compare with the datetimes of the base and remote.
+func (t *TOMLConfigImpl) getLocalMergeTree(ptrConfigObject interface{}, base *Tree) (*Tree, error) {
+	configObject := reflect.ValueOf(ptrConfigObject).Elem().Interface()
+	cfgLines, _ := t.newEncoder(nil).translateToString(configObject)
+	local, err := Parse([]byte(cfgLines))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range local.Keys() {
+		switch value := getFieldValueByKey(configObject, key, t.NamingStrategy).(type) {
+		case time.Time, []time.Time:
+			if err = local.Set(key, value); err != nil {
+				return nil, err
+			}
+		}
+	}	// end -- for (local keys)
+	for _, key := range base.Keys() {
+		if !local.Has(key) {
+			if err = local.Set(key, base.Get(key)); err != nil {
+				return nil, err
+			}
+		}
+	}	// end -- for (base keys)
+	return local, nil
+}
+
+// remember the contents of the config file as the base of Merge /
+// DetectConflicts.
+func (t *TOMLConfigImpl) rememberBase(configFilenameOrPath string, contents []byte) {
+	t.baseContents = contents
+	t.basePath = configFilenameOrPath
+	t.baseChecksum = sha256.Sum256(contents)
+}
+
+// check if the config file was modified (or removed) since the base was
+// remembered; false if there is no base of the file.
+func (t *TOMLConfigImpl) isBaseModified(configFilenameOrPath string) bool {
+	if t.baseContents == nil || !isSameConfigPath(configFilenameOrPath, t.basePath) {
+		return false
+	}
+	contents, err := common.LoadFile(configFilenameOrPath)
+	if err != nil {
+		return true
+	}
+	return sha256.Sum256(contents) != t.baseChecksum
+}
+
+// check if the 2 paths refer to the same config file (empty paths never match).
+func isSameConfigPath(pathA, pathB string) bool {
+	if pathA == "" || pathB == "" {
+		return false
+	}
+	return strings.Compare(filepath.Clean(pathA), filepath.Clean(pathB)) == 0
+}
+
+// compare 2 values by their toml representation (nil => absent).
+func isMergeValueEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return formatNodeValue(a) == formatNodeValue(b)
+}
+
+// format the value for a conflict summary; absent values are "(none)".
+func formatMergeValue(value interface{}) string {
+	if value == nil {
+		return "(none)"
+	}
+	return formatChangeSummaryValue(value)
+}
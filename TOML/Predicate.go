@@ -0,0 +1,500 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package TOML
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+/*
+ *	predicate syntax
+ *		path op value					e.g. workingHoursDay > 6
+ *		path in [value, value ...]		e.g. role in [admin, ops]
+ *		path contains value				e.g. hobbies contains soccer
+ *		path							e.g. activeProfile (a bool path equals true)
+ *
+ *	op => ==, !=, >, >=, <, <=
+ *	predicates could be combined with "and" (&&), "or" (||), "not" (!) and
+ *	parentheses. Values could be quoted ("..." or '...') when they contain
+ *	spaces or operator symbols (e.g. "2018-05-01 11:59:59").
+ */
+
+// a parsed predicate expression; could be evaluated against any number of
+// config Structs (check ParsePredicate).
+type Predicate struct {
+	Expression string
+	root predicateNode
+}
+
+// parse the expression into a Predicate; returns an error if the
+// expression is invalid (e.g. missing operator, unbalanced parentheses).
+func ParsePredicate(expression string) (*Predicate, error) {
+	tokens, err := tokenizePredicate(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid predicate [%v]: %v", expression, err)
+	}
+	parser := predicateParser{ tokens: tokens }
+	root, err := parser.parseOr()
+	if err == nil && parser.pos < len(parser.tokens) {
+		err = fmt.Errorf("unexpected [%v]", parser.tokens[parser.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid predicate [%v]: %v", expression, err)
+	}
+	return &Predicate{ Expression: expression, root: root }, nil
+}
+
+// evaluate the predicate against the given config Struct (value or pointer);
+// paths are resolved by common.GetValueByPath and values are coerced into
+// the type of the path's value with the same rules as loading (ints, floats,
+// strings, bools, time.Time and the slices of them). Returns an error if a
+// path could not be resolved or a value could not be compared.
+func (p *Predicate) Evaluate(configObject interface{}, strategy ...common.KeyNamingStrategy) (bool, error) {
+	return p.root.evaluate(configObject, strategy)
+}
+
+// return the expression of the predicate.
+func (p *Predicate) String() string {
+	return p.Expression
+}
+
+// parse and evaluate the expression against the given config Struct
+// e.g. Match(&config, "role in [admin, ops] and workingHoursDay > 6")
+// (check ParsePredicate and Predicate.Evaluate).
+func Match(configObject interface{}, expression string, strategy ...common.KeyNamingStrategy) (bool, error) {
+	predicate, err := ParsePredicate(expression)
+	if err != nil {
+		return false, err
+	}
+	return predicate.Evaluate(configObject, strategy...)
+}
+
+/* ------------------- */
+/*	evaluation		   */
+/* ------------------- */
+
+type predicateNode interface {
+	evaluate(configObject interface{}, strategy []common.KeyNamingStrategy) (bool, error)
+}
+
+// "and" / "or" of 2 predicates (short-circuited).
+type logicalNode struct {
+	isAnd bool
+	left predicateNode
+	right predicateNode
+}
+
+func (n *logicalNode) evaluate(configObject interface{}, strategy []common.KeyNamingStrategy) (bool, error) {
+	matched, err := n.left.evaluate(configObject, strategy)
+	if err != nil {
+		return false, err
+	}
+	if matched != n.isAnd {
+		return matched, nil
+	}
+	return n.right.evaluate(configObject, strategy)
+}
+
+type notNode struct {
+	operand predicateNode
+}
+
+func (n *notNode) evaluate(configObject interface{}, strategy []common.KeyNamingStrategy) (bool, error) {
+	matched, err := n.operand.evaluate(configObject, strategy)
+	return !matched, err
+}
+
+// comparison of a path's value against the given value(s).
+type comparisonNode struct {
+	path string
+	operator string
+	values []string
+	isList bool
+}
+
+func (n *comparisonNode) evaluate(configObject interface{}, strategy []common.KeyNamingStrategy) (bool, error) {
+	val, err := common.GetValueByPath(configObject, n.path, strategy...)
+	if err != nil {
+		return false, err
+	}
+	val = getPredicateValue(val)
+
+	switch n.operator {
+	case "in":
+		for _, value := range n.values {
+			cmp, _, err := comparePredicateValue(val, value, n.path)
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				return true, nil
+			}
+		}	// end -- for (values)
+		return false, nil
+
+	case "contains":
+		if val.Kind() == reflect.String {
+			return strings.Contains(val.String(), n.values[0]), nil
+		}
+		if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+			return false, &common.PathError{ Path: n.path,
+				Reason: fmt.Sprintf("[contains] is not supported by [%v]", val.Type()) }
+		}
+		for i := 0; i < val.Len(); i++ {
+			cmp, _, err := comparePredicateValue(getPredicateValue(val.Index(i)), n.values[0], n.path)
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				return true, nil
+			}
+		}	// end -- for (elements)
+		return false, nil
+	}
+
+	// ==, !=, >, >=, <, <=
+	var cmp int
+	var ordered bool
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		cmp, err = compareArrayValue(val, n)
+	} else if n.isList {
+		err = &common.PathError{ Path: n.path, Reason: fmt.Sprintf("cannot compare [%v] with an array", val.Type()) }
+	} else {
+		cmp, ordered, err = comparePredicateValue(val, n.values[0], n.path)
+	}
+	if err != nil {
+		return false, err
+	}
+	switch n.operator {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	}
+	if !ordered {
+		return false, &common.PathError{ Path: n.path,
+			Reason: fmt.Sprintf("[%v] is not supported by [%v]", n.operator, val.Type()) }
+	}
+	switch n.operator {
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	}
+	return cmp <= 0, nil
+}
+
+// follow the pointers and interfaces (e.g. map[string]interface{} entries).
+func getPredicateValue(val reflect.Value) reflect.Value {
+	for (val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr) && !val.IsNil() {
+		val = val.Elem()
+	}
+	return val
+}
+
+// arrays support == and != only; the elements are compared in order.
+func compareArrayValue(val reflect.Value, n *comparisonNode) (int, error) {
+	if !n.isList || (n.operator != "==" && n.operator != "!=") {
+		return 0, &common.PathError{ Path: n.path,
+			Reason: fmt.Sprintf("[%v %v] is not supported by [%v]", n.operator, strings.Join(n.values, ", "), val.Type()) }
+	}
+	if val.Len() != len(n.values) {
+		return 1, nil
+	}
+	for i, value := range n.values {
+		cmp, _, err := comparePredicateValue(getPredicateValue(val.Index(i)), value, n.path)
+		if err != nil || cmp != 0 {
+			return 1, err
+		}
+	}	// end -- for (values)
+	return 0, nil
+}
+
+// compare the value with the string value coerced into the value's type;
+// returns -1, 0 or 1 plus whether the type supports ordering (bool does not).
+func comparePredicateValue(val reflect.Value, value, path string) (int, bool, error) {
+	if !val.IsValid() || ((val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr) && val.IsNil()) {
+		return 0, false, &common.PathError{ Path: path, Reason: "cannot compare a nil value" }
+	}
+	if tVal, ok := val.Interface().(time.Time); ok {
+		other, err := common.CoerceValue(value, val.Type(), path)
+		if err != nil {
+			return 0, false, err
+		}
+		oVal := other.Interface().(time.Time)
+		if tVal.Before(oVal) {
+			return -1, true, nil
+		} else if tVal.After(oVal) {
+			return 1, true, nil
+		}
+		return 0, true, nil
+	}
+
+	switch {
+	case common.IsNumericKind(val.Kind()):
+		fVal, err := common.ParseStringToFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, false, &common.PathError{ Path: path,
+				Reason: fmt.Sprintf("cannot convert [%v] to %v type", value, val.Type()) }
+		}
+		current := val.Convert(reflect.TypeOf(fVal)).Float()
+		if current < fVal {
+			return -1, true, nil
+		} else if current > fVal {
+			return 1, true, nil
+		}
+		return 0, true, nil
+
+	case val.Kind() == reflect.String:
+		return strings.Compare(val.String(), value), true, nil
+
+	case val.Kind() == reflect.Bool:
+		other, err := common.CoerceValue(value, val.Type(), path)
+		if err != nil {
+			return 0, false, err
+		}
+		if val.Bool() == other.Bool() {
+			return 0, false, nil
+		}
+		return 1, false, nil
+	}
+	return 0, false, &common.PathError{ Path: path, Reason: fmt.Sprintf("cannot compare [%v] with [%v]", val.Type(), value) }
+}
+
+/* ------------------- */
+/*	parsing			   */
+/* ------------------- */
+
+const (
+	tokenWord = iota
+	tokenQuoted
+	tokenSymbol
+)
+
+type predicateToken struct {
+	kind int
+	text string
+}
+
+// split the expression into words, quoted values and symbols; a path's
+// indices (e.g. hobbies[1]) are part of the word.
+func tokenizePredicate(expression string) ([]predicateToken, error) {
+	tokens := make([]predicateToken, 0)
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote at [%v]", i)
+			}
+			tokens = append(tokens, predicateToken{ kind: tokenQuoted, text: string(runes[i+1:end]) })
+			i = end + 1
+		case strings.ContainsRune("()[],", r):
+			tokens = append(tokens, predicateToken{ kind: tokenSymbol, text: string(r) })
+			i++
+		case strings.ContainsRune("=!<>", r):
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, predicateToken{ kind: tokenSymbol, text: string(runes[i:i+2]) })
+				i += 2
+			} else if r == '=' {
+				return nil, fmt.Errorf("unknown operator [=] at [%v] (use ==)", i)
+			} else {
+				tokens = append(tokens, predicateToken{ kind: tokenSymbol, text: string(r) })
+				i++
+			}
+		case (r == '&' || r == '|') && i+1 < len(runes) && runes[i+1] == r:
+			tokens = append(tokens, predicateToken{ kind: tokenSymbol, text: string(runes[i:i+2]) })
+			i += 2
+		default:
+			end := i
+			for end < len(runes) && !strings.ContainsRune(" \t\n\r\"'()],=!<>", runes[end]) {
+				if runes[end] == '[' {
+					// indices of a path
+					for end < len(runes) && runes[end] != ']' {
+						end++
+					}
+					if end >= len(runes) {
+						return nil, fmt.Errorf("unterminated index at [%v]", i)
+					}
+				}
+				end++
+			}
+			tokens = append(tokens, predicateToken{ kind: tokenWord, text: string(runes[i:end]) })
+			i = end
+		}
+	}	// end -- for (runes)
+	return tokens, nil
+}
+
+type predicateParser struct {
+	tokens []predicateToken
+	pos int
+}
+
+// return the next token's text if it is a symbol or a keyword (lowercased).
+func (p *predicateParser) peekOperator() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind == tokenQuoted {
+		return ""
+	}
+	return strings.ToLower(p.tokens[p.pos].text)
+}
+
+func (p *predicateParser) parseOr() (predicateNode, error) {
+	left, err := p.parseAnd()
+	for err == nil {
+		if op := p.peekOperator(); op != "or" && op != "||" {
+			break
+		}
+		p.pos++
+		var right predicateNode
+		right, err = p.parseAnd()
+		left = &logicalNode{ isAnd: false, left: left, right: right }
+	}
+	return left, err
+}
+
+func (p *predicateParser) parseAnd() (predicateNode, error) {
+	left, err := p.parseUnary()
+	for err == nil {
+		if op := p.peekOperator(); op != "and" && op != "&&" {
+			break
+		}
+		p.pos++
+		var right predicateNode
+		right, err = p.parseUnary()
+		left = &logicalNode{ isAnd: true, left: left, right: right }
+	}
+	return left, err
+}
+
+func (p *predicateParser) parseUnary() (predicateNode, error) {
+	switch p.peekOperator() {
+	case "not", "!":
+		p.pos++
+		operand, err := p.parseUnary()
+		return &notNode{ operand: operand }, err
+	case "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekOperator() != ")" {
+			return nil, fmt.Errorf("missing [)]")
+		}
+		p.pos++
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *predicateParser) parseComparison() (predicateNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("missing path")
+	}
+	if p.tokens[p.pos].kind != tokenWord {
+		return nil, fmt.Errorf("expected a path; got [%v]", p.tokens[p.pos].text)
+	}
+	node := &comparisonNode{ path: p.tokens[p.pos].text }
+	p.pos++
+
+	op := p.peekOperator()
+	switch op {
+	case "==", "!=", ">", ">=", "<", "<=", "in", "contains":
+		node.operator = op
+		p.pos++
+	case "", ")", "and", "&&", "or", "||":
+		// a bare path => equals true
+		node.operator = "=="
+		node.values = []string{ "true" }
+		return node, nil
+	default:
+		return nil, fmt.Errorf("unknown operator [%v] after [%v]", p.tokens[p.pos].text, node.path)
+	}
+
+	if p.peekOperator() == "[" {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		node.values = values
+		node.isList = true
+	} else {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		node.values = []string{ value }
+	}
+	if op == "in" && !node.isList {
+		return nil, fmt.Errorf("[in] expects a list e.g. [a, b]")
+	}
+	if op == "contains" && node.isList {
+		return nil, fmt.Errorf("[contains] expects a single value")
+	}
+	return node, nil
+}
+
+func (p *predicateParser) parseValue() (string, error) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind == tokenSymbol {
+		return "", fmt.Errorf("missing value")
+	}
+	p.pos++
+	return p.tokens[p.pos-1].text, nil
+}
+
+func (p *predicateParser) parseList() ([]string, error) {
+	values := make([]string, 0)
+	// skip the [
+	p.pos++
+	if p.peekOperator() == "]" {
+		p.pos++
+		return values, nil
+	}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		switch p.peekOperator() {
+		case ",":
+			p.pos++
+		case "]":
+			p.pos++
+			return values, nil
+		default:
+			return nil, fmt.Errorf("missing []] in the list")
+		}
+	}	// end -- for (values)
+}
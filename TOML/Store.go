@@ -0,0 +1,167 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package TOML
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/quoeamaster/CFactor/common"
+	"github.com/quoeamaster/CFactor/interfaces"
+)
+
+// a thread-safe holder of the config Struct (type T) for services reading
+// the config from many goroutines while it is reloaded somewhere else.
+// Every Load decodes into a fresh T, validates it and swaps the snapshot
+// pointer atomically; Get is lock-free and never sees a half populated
+// value. A snapshot is shared by all readers hence it must be treated as
+// read-only (use Swap to publish a modified copy).
+type Store[T any] struct {
+	// the config backend used by Load (e.g. a *TOMLConfigImpl); its options
+	// (e.g. NamingStrategy) could be set before the first Load.
+	Config interfaces.IConfig
+
+	// optional validation invoked on the fresh value after the Tag rules and
+	// the IConfigValidator hooks; an error keeps the current snapshot.
+	Validate func(config *T) error
+
+	// the current snapshot (nil before the first Load / Swap)
+	current atomic.Pointer[T]
+	// serializes Load and Swap (the Config is not thread-safe)
+	lock sync.Mutex
+
+	// the OnChange subscriptions (in registration order)
+	subscriptions []changeSubscription
+	subscriptionLock sync.Mutex
+}
+
+// a subscription to the changes of a key (or keys under a prefix).
+type changeSubscription struct {
+	pattern string
+	callback func(oldValue, newValue interface{})
+}
+
+// create a Store loading the toml config file "name" into T.
+func NewStore[T any](name string) *Store[T] {
+	var zero T
+	config := NewTOMLConfigImpl(name, reflect.TypeOf(zero))
+	return &Store[T]{ Config: &config }
+}
+
+// return the current snapshot (nil if nothing is loaded yet); lock-free.
+func (s *Store[T]) Get() *T {
+	return s.current.Load()
+}
+
+// load the config file into a fresh T, validate it and swap it in as the
+// current snapshot; on any error the current snapshot is kept untouched.
+// Returns the new snapshot.
+func (s *Store[T]) Load() (*T, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.Config == nil {
+		return s.current.Load(), errors.New("no Config available for the Store")
+	}
+	config := new(T)
+	// Config.Load runs the Tag rules and the IConfigValidator hooks
+	if _, err := s.Config.Load(config); err != nil {
+		return s.current.Load(), err
+	}
+	if s.Validate != nil {
+		if err := s.Validate(config); err != nil {
+			return s.current.Load(), err
+		}
+	}
+	s.notifyChanges(s.current.Swap(config), config)
+	return config, nil
+}
+
+// validate the given value (same as Load) and swap it in as the current
+// snapshot; the value must not be modified afterwards. Returns the previous
+// snapshot; on any error the current snapshot is kept untouched.
+func (s *Store[T]) Swap(config *T) (*T, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if config == nil {
+		return s.current.Load(), errors.New("a nil config could not be swapped in")
+	}
+	if err := common.ValidateFieldValuesByStrategy(config, nil, s.namingStrategy()); err != nil {
+		return s.current.Load(), err
+	}
+	if s.Validate != nil {
+		if err := s.Validate(config); err != nil {
+			return s.current.Load(), err
+		}
+	}
+	previous := s.current.Swap(config)
+	s.notifyChanges(previous, config)
+	return previous, nil
+}
+
+// subscribe to the changes of the key (e.g. "client.address.city") or the
+// keys under a prefix (e.g. "broker.*"; "*" => all keys). After every
+// successful Load / Swap replacing a snapshot, the callback is invoked once
+// per changed key with the old and new values (nil if the key is added /
+// removed). Callbacks run on the goroutine of the Load / Swap in key order
+// and must not call Load / Swap themselves.
+func (s *Store[T]) OnChange(pattern string, callback func(oldValue, newValue interface{})) {
+	s.subscriptionLock.Lock()
+	defer s.subscriptionLock.Unlock()
+	s.subscriptions = append(s.subscriptions, changeSubscription{ pattern: pattern, callback: callback })
+}
+
+// invoke the subscriptions matching the changed keys of the snapshots.
+func (s *Store[T]) notifyChanges(previous, current *T) {
+	s.subscriptionLock.Lock()
+	subscriptions := append([]changeSubscription{}, s.subscriptions...)
+	s.subscriptionLock.Unlock()
+
+	if previous == nil || len(subscriptions) == 0 {
+		return
+	}
+	for _, change := range Diff(previous, current, s.namingStrategy()) {
+		for _, subscription := range subscriptions {
+			if isKeyPatternMatched(subscription.pattern, change.Key) {
+				subscription.callback(change.OldValue, change.NewValue)
+			}
+		}	// end -- for (subscriptions)
+	}	// end -- for (changes)
+}
+
+// the naming strategy of the Config (NamingNone if not a *TOMLConfigImpl).
+func (s *Store[T]) namingStrategy() common.KeyNamingStrategy {
+	if impl, ok := s.Config.(*TOMLConfigImpl); ok {
+		return impl.NamingStrategy
+	}
+	return common.NamingNone
+}
+
+// check if the key matches the pattern (a key, "prefix.*" or "*").
+func isKeyPatternMatched(pattern, key string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(key, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == key
+}
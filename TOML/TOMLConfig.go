@@ -21,13 +21,15 @@ package TOML
 import (
 	"reflect"
 	"strings"
-	"runtime"
+	"io"
 	"time"
-	"bufio"
 	"fmt"
 	"errors"
 	"bytes"
+	"crypto/sha256"
+	"path/filepath"
 	"github.com/quoeamaster/CFactor/common"
+	"github.com/quoeamaster/CFactor/interfaces"
 )
 
 // "CFactor/common"
@@ -41,8 +43,55 @@ type TOMLConfigImpl struct {
 	// translated into. Simply the corresponding fields of the
 	// supplied Struct would be populated accordingly.
 	StructType reflect.Type
+
+	// strategy to derive the keys of the fields without a toml Tag
+	// (check common.KeyNamingStrategy); by default such fields are ignored.
+	NamingStrategy common.KeyNamingStrategy
+
+	// optional callback invoked for every deprecated key (alias:"..." or
+	// deprecated:"..." Tags) found in the config file during loading.
+	OnDeprecatedKey func(warning common.DeprecationWarning)
+
+	// Save writes the keys sorted alphabetically instead of the Struct
+	// field order (scalars are always written before the tables).
+	SortKeys bool
+
+	// Save writes the child Structs as dotted keys (e.g. client.address.city = "Seoul")
+	// instead of [table] sections (e.g. [client.address] + city = "Seoul").
+	DottedKeys bool
+
+	// Load remembers the file's Document (comments, blank lines, whitespace
+	// and key order); saving back to the same file rewrites the lines of the
+	// changed values only instead of regenerating the whole file.
+	PreserveFormat bool
+
+	// the number of rotated backups (e.g. config.toml.bak.1) of the previous
+	// contents kept on Save; 0 => no backups.
+	Backups int
+
+	// Save fails with ErrConfigModified if the config file was modified by
+	// someone else (content hash) since the last Load / Save; check Merge to
+	// combine the concurrent edits.
+	DetectConflicts bool
+
+	// the Document remembered by the last Load (PreserveFormat only)
+	document *Document
+	// the file of the remembered Document
+	documentPath string
+	// the toml representation of the values at the last Load / Save
+	// (key => value); used to detect the changed values
+	documentValues map[string]string
+
+	// the contents of the config file at the last Load / write (the base
+	// of Merge) plus its file and checksum
+	baseContents []byte
+	basePath string
+	baseChecksum [sha256.Size]byte
 }
 
+// TOMLConfigImpl is the TOML backend of interfaces.IConfig.
+var _ interfaces.IConfig = (*TOMLConfigImpl)(nil)
+
 // create a new TOMLConfigImpl instance.
 func NewTOMLConfigImpl(name string, structType reflect.Type) TOMLConfigImpl {
 	impl := TOMLConfigImpl{
@@ -56,116 +105,392 @@ func NewTOMLConfigImpl(name string, structType reflect.Type) TOMLConfigImpl {
 // load the toml config file based on TOMLConfigImpl.Name property.
 // A reference of the targeted Struct Type is given; this reference's fields
 // would be populated accordingly based on the targeted Struct's Tag setup.
+// The optional BeforeLoad / AfterLoad hooks are invoked around the population
+// (check IConfig.go for the order).
+// Fields with a "validate" Tag are validated after population, followed by
+// the IConfigValidator hook (bottom-up); failures are returned as a
+// *common.ValidationError.
 // Returns the same reference plus any Error occurred during the
 // loading operation.
 func (t *TOMLConfigImpl) Load(ptrConfigObject interface{}) (ptr interface{}, err error) {
-	// defer
-	defer func() {
-		if r := recover(); r != nil {
-			if _, ok := r.(runtime.Error); ok {
-				// runtime error, check if anything could be helped to continue the program
-				panic(r)
-			}
-			//err = r.(error)
-			err = fmt.Errorf("%v", r)
-		}
-	}()
+	ptr, _, err = t.LoadWithMetaData(ptrConfigObject)
+	return ptr, err
+}
 
+// load the toml config file based on TOMLConfigImpl.Name property (same as Load).
+// Additionally returns the meta data of the loading operation, which lists
+// the keys defined, undecoded and defaulted plus the source line of each key;
+// handy to check if a key was explicitly set (e.g. metaData.IsDefined("author.age")).
+func (t *TOMLConfigImpl) LoadWithMetaData(ptrConfigObject interface{}) (ptr interface{}, metaData common.DecodeMetaData, err error) {
 	// load the contents of the given "name"
 	bBytes, err := common.LoadFile(t.Name)
+	if err != nil {
+		return reflect.Zero(t.StructType), common.NewDecodeMetaData(), err
+	}
+	metaData, err = t.newDecoder(bytes.NewReader(bBytes)).DecodeWithMetaData(ptrConfigObject)
+	if err != nil {
+		return ptrConfigObject, metaData, err
+	}
+	t.rememberBase(t.Name, bBytes)
+	if t.PreserveFormat {
+		t.document = ParseDocument(bBytes)
+		t.documentPath = t.Name
+		_, t.documentValues = t.getTomlValuesInString(ptrConfigObject)
+	}
+	return ptrConfigObject, metaData, nil
+}
+
+// read the toml contents from the given reader and populate the given
+// Struct pointer (same as Load with the contents not coming from the
+// config file); the options of the TOMLConfigImpl are honoured.
+func (t *TOMLConfigImpl) Decode(reader io.Reader, ptrConfigObject interface{}) error {
+	return t.newDecoder(reader).Decode(ptrConfigObject)
+}
+
+// encode the given Struct (value or pointer) and write the toml contents
+// to the given writer (same as Save without the config file); the options
+// of the TOMLConfigImpl are honoured.
+func (t *TOMLConfigImpl) Encode(writer io.Writer, configObject interface{}) error {
+	return t.newEncoder(writer).Encode(configObject)
+}
+
+// create a Decoder sharing the options of the TOMLConfigImpl.
+func (t *TOMLConfigImpl) newDecoder(reader io.Reader) *Decoder {
+	decoder := NewDecoder(reader)
+	decoder.StructType = t.StructType
+	decoder.NamingStrategy = t.NamingStrategy
+	decoder.OnDeprecatedKey = t.OnDeprecatedKey
+	decoder.Name = t.Name
+	return decoder
+}
 
-	if err == nil {
-		// build the object based on the given Type plus populate the contents loaded into bBytes
-		lines := common.GetLinesFromByteArrayContent(bBytes)
-		ok, err := common.PopulateFieldValues(lines, common.ConfigTypeTOML, ptrConfigObject, t.StructType)
-		if !ok && err!=nil {
-			return ptrConfigObject, err
+// create an Encoder sharing the options of the TOMLConfigImpl.
+func (t *TOMLConfigImpl) newEncoder(writer io.Writer) *Encoder {
+	encoder := NewEncoder(writer)
+	encoder.NamingStrategy = t.NamingStrategy
+	encoder.SortKeys = t.SortKeys
+	encoder.DottedKeys = t.DottedKeys
+	return encoder
+}
+
+// persist the provided Struct reference's fields value back to the
+// config file. The values are validated (validate Tag plus the
+// IConfigValidator hook) before persisting; nothing is written if the
+// validation fails. The optional BeforeSave / AfterSave hooks are invoked
+// around the operation (check IConfig.go for the order); "configObject"
+// could be a Struct value or a pointer to the Struct, changes made by the
+// BeforeSave hooks are only visible to the caller in the latter case.
+// The keys are written in Struct field order (or sorted if SortKeys is set)
+// with the scalars before the child Structs, hence the output is stable.
+// If DetectConflicts is set and the config file was modified since the
+// last Load / Save, an error wrapping ErrConfigModified is returned and
+// nothing is written.
+// Return the error occurred during the operation.
+func (t *TOMLConfigImpl) Save(configFilenameOrPath string, configObject interface{}) (err error) {
+	if t.DetectConflicts && t.isBaseModified(configFilenameOrPath) {
+		return fmt.Errorf("%w [%v]", ErrConfigModified, configFilenameOrPath)
+	}
+	configObjectPtr, err := t.newEncoder(nil).prepareConfigObject(configObject)
+	if err != nil {
+		return err
+	}
+	configObject = reflect.ValueOf(configObjectPtr).Elem().Interface()
+
+	if err = t.saveStructValues(configFilenameOrPath, configObject); err != nil {
+		return err
+	}
+	return common.InvokeAfterSaveHooks(configObjectPtr)
+}
+
+/* ------------------------------------ */
+/*	file level key operations			*/
+/* ------------------------------------ */
+
+// set the value of the given key (e.g. "client.address.city") in the config
+// file (TOMLConfigImpl.Name) without a Struct; the file is edited in place
+// (comments and formatting are kept). A missing key is added to its
+// [table], the table is created if necessary.
+func (t *TOMLConfigImpl) SetKey(key string, value interface{}) error {
+	valueInString, err := formatTomlValue(value)
+	if err != nil {
+		return fmt.Errorf("could not set key [%v] => %v", key, err)
+	}
+	return t.editDocument(func(doc *Document) error {
+		doc.SetValue(key, valueInString)
+		return nil
+	})
+}
+
+// remove the given key from the config file (TOMLConfigImpl.Name); the file
+// is edited in place.
+func (t *TOMLConfigImpl) DeleteKey(key string) error {
+	return t.editDocument(func(doc *Document) error {
+		if !doc.DeleteKey(key) {
+			return fmt.Errorf("key [%v] not found in [%v]", key, t.Name)
 		}
-		/*
-		for _, v := range lines {
-			ok, err := common.PopulateFieldValues(v, common.ConfigTypeTOML, ptrConfigObject, t.StructType)
-			if !ok && err!=nil {
-				return ptrConfigObject, err
-			}
-		}*/
-		return ptrConfigObject, nil
+		return nil
+	})
+}
+
+// rename the given key in the config file (TOMLConfigImpl.Name); the value
+// and the inline comment are kept, the file is edited in place.
+func (t *TOMLConfigImpl) RenameKey(oldKey, newKey string) error {
+	return t.editDocument(func(doc *Document) error {
+		if !doc.HasKey(oldKey) {
+			return fmt.Errorf("key [%v] not found in [%v]", oldKey, t.Name)
+		}
+		if !doc.RenameKey(oldKey, newKey) {
+			return fmt.Errorf("key [%v] already exists in [%v]", newKey, t.Name)
+		}
+		return nil
+	})
+}
+
+// load the config file as a Document, apply the edit and write it back.
+func (t *TOMLConfigImpl) editDocument(edit func(doc *Document) error) error {
+	doc, err := LoadDocument(t.Name)
+	if err != nil {
+		return err
+	}
+	if err = edit(doc); err != nil {
+		return err
+	}
+	if err = t.writeConfigFile(t.Name, string(doc.Bytes())); err != nil {
+		return err
+	}
+	// keep the remembered Document (PreserveFormat) in sync
+	if t.document != nil && strings.Compare(filepath.Clean(t.Name), filepath.Clean(t.documentPath)) == 0 {
+		t.document = doc
 	}
-	return reflect.Zero(t.StructType), err
+	return nil
 }
 
-// persist the provided Struct reference's fields value back to the
-// config file. Return the error occurred during the operation.
-func (t *TOMLConfigImpl) Save(configFilenameOrPath string, structType reflect.Type, configObject interface{}) (err error) {
-	err = nil
-	// create a Map[string]object structure for the available config tags
-	configMap := make(map[string]interface{})
-	numFields := structType.NumField()
-
-	for idx := 0; idx < numFields; idx++ {
-		fieldMeta := structType.Field(idx)
-		ok := common.IsFieldValueEmptyOrNil(configObject, idx) // , fieldMeta
-		if ok==false {
-			tagValue := fieldMeta.Tag.Get(common.TagTOML)
-			configMap[tagValue] = common.GetValueByTomlFieldNType(configObject, structType, fieldMeta.Name)
-		}	// end -- if (handle the non-nil values)
-	}	// end -- for (numFields loop)
-
-	if len(configMap) > 0 {
-		cfgFile := common.CreateFile(configFilenameOrPath)
-		cfgWriter := bufio.NewWriter(cfgFile)
-		// sort of finally clause
-		defer func() {
-			// display some info about the error???
-			cfgWriter.Flush()
-			cfgFile.Close()
-			/*
-			 *	try to catch the err and return out to the caller instead of panic =>
-			 *	https://stackoverflow.com/questions/19934641/go-returning-from-defer
-			 */
-			if r := recover(); r != nil {
-				switch r.(type) {
-				case error:
-					err = r.(error)
-				default:
-					errLine := fmt.Sprintf("unknown error => %v", r)
-					err = errors.New(errLine)
-				}
-			}
-		}()
-
-		for key, value := range configMap {
-			cfgLine := ""
-			// check if it is array (has different format)
-			cfgLine, bMatched := translateArrayValueToStringFormat(value, key)
-			if !bMatched {
-				// check if it is non primitive type such as struct
-				cfgLine, bMatched, err = translateNonPrimitiveValueToString(value)
-				if err != nil {
-					return err
-				}
-				if !bMatched {
-					cfgLine = fmt.Sprintf("%v = %v\n", key, value)
-				}	// end -- if (non array + non primitive)
-			}	// end -- if (non array)
-
-			_, err := cfgWriter.WriteString(cfgLine)
-			if err != nil {
-				return err
+// format the given value into its toml representation (e.g. strings and
+// time.Time are surrounded by ").
+func formatTomlValue(value interface{}) (string, error) {
+	val := reflect.Indirect(reflect.ValueOf(value))
+	if !val.IsValid() {
+		return "", errors.New("the value is nil")
+	}
+	if scalar, ok := common.GetTomlScalarValue(val); ok {
+		return formatScalarValue(scalar), nil
+	}
+	switch val.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%v", val.Interface()), nil
+	}
+	return "", fmt.Errorf("unsupported value type [%v]", val.Type())
+}
+
+// return a pointer to the given Struct; if a Struct value is given, a
+// pointer to a copy of the value is returned.
+func getStructPointer(configObject interface{}) interface{} {
+	objVal := reflect.ValueOf(configObject)
+	if objVal.Kind() == reflect.Ptr {
+		return configObject
+	}
+	ptrVal := reflect.New(objVal.Type())
+	ptrVal.Elem().Set(objVal)
+	return ptrVal.Interface()
+}
+
+// persist the Struct value's fields to the config file.
+func (t *TOMLConfigImpl) saveStructValues(configFilenameOrPath string, configObject interface{}) (err error) {
+	if t.PreserveFormat && t.document != nil &&
+		strings.Compare(filepath.Clean(configFilenameOrPath), filepath.Clean(t.documentPath)) == 0 {
+		return t.saveDocumentValues(configFilenameOrPath, configObject)
+	}
+	if cfgLines, ok := t.newEncoder(nil).translateToString(configObject); ok {
+		return t.writeConfigFile(configFilenameOrPath, cfgLines)
+	}
+	return nil
+}
+
+// update the remembered Document with the changed values only and write it
+// back; comments, blank lines and the unchanged lines are kept as-is. Keys
+// removed from the Struct's values (e.g. omitempty) are removed from the
+// Document as well.
+func (t *TOMLConfigImpl) saveDocumentValues(configFilenameOrPath string, configObject interface{}) error {
+	keys, values := t.getTomlValuesInString(configObject)
+
+	for _, key := range keys {
+		if oldValue, ok := t.documentValues[key]; ok && strings.Compare(oldValue, values[key]) == 0 {
+			continue
+		}
+		t.document.SetValue(key, values[key])
+	}	// end -- for (changed values)
+	for key := range t.documentValues {
+		if _, ok := values[key]; !ok {
+			t.document.DeleteKey(key)
+		}
+	}	// end -- for (removed values)
+
+	if err := t.writeConfigFile(configFilenameOrPath, string(t.document.Bytes())); err != nil {
+		return err
+	}
+	t.documentValues = values
+	return nil
+}
+
+// return the toml representation of the Struct's values (full key => value)
+// plus the full keys in Struct field order.
+func (t *TOMLConfigImpl) getTomlValuesInString(configObject interface{}) ([]string, map[string]string) {
+	keys := []string{}
+	values := make(map[string]string)
+	for _, kv := range flattenTomlKeyValues(common.GetTomlKeyValuesByStrategy(configObject, t.NamingStrategy, false)) {
+		keys = append(keys, kv.Key)
+		values[kv.Key] = formatScalarValue(kv.Value)
+	}
+	return keys, values
+}
+
+// return the scalar key values of the tables (nested ones included) at the same level.
+func flattenTomlKeyValues(keyValues []common.TomlKeyValue) []common.TomlKeyValue {
+	flattened := []common.TomlKeyValue{}
+	for _, kv := range keyValues {
+		if kv.IsTable() {
+			flattened = append(flattened, flattenTomlKeyValues(kv.Value.([]common.TomlKeyValue))...)
+		} else {
+			flattened = append(flattened, kv)
+		}
+	}
+	return flattened
+}
+
+// write the contents into the config file; the file is replaced atomically
+// (check common.WriteFileAtomic) so a failed Save never leaves a truncated
+// config behind. Saving to another file (e.g. a backup copy) keeps the
+// base of the loaded config file.
+func (t *TOMLConfigImpl) writeConfigFile(configFilenameOrPath string, cfgLines string) error {
+	if err := common.WriteFileAtomic(configFilenameOrPath, []byte(cfgLines), t.Backups); err != nil {
+		return err
+	}
+	if isSameConfigPath(configFilenameOrPath, t.basePath) || isSameConfigPath(configFilenameOrPath, t.Name) {
+		t.rememberBase(configFilenameOrPath, []byte(cfgLines))
+	}
+	return nil
+}
+
+// translate the key values into toml lines (dotted key style); the scalars
+// come first and every table (child Struct) is separated by an empty line.
+func translateKeyValuesToDottedString(keyValues []common.TomlKeyValue) string {
+	var bBuffer bytes.Buffer
+
+	for _, kv := range keyValues {
+		if kv.IsTable() {
+			if bBuffer.Len() > 0 {
+				bBuffer.WriteString("\n")
 			}
-		}	// end -- for (all entries inside the config map
-	}	// end -- if (configMap has some elements)
-	return err
+			bBuffer.WriteString(translateCommentToString(kv.Comment))
+			bBuffer.WriteString(translateKeyValuesToDottedString(kv.Value.([]common.TomlKeyValue)))
+			continue
+		}
+		bBuffer.WriteString(translateKeyValueToString(kv.Key, kv))
+	}	// end -- for (keyValues)
+	return bBuffer.String()
+}
+
+// translate the key values into toml lines with a [table] section per
+// table (child Struct or map) e.g. [client], [client.address]; keys of a
+// table not prefixed by the table's key could not live under the table's
+// section, hence they are written as dotted keys before the first section.
+func translateKeyValuesToTableString(keyValues []common.TomlKeyValue) string {
+	var rootBuffer, tablesBuffer bytes.Buffer
+	translateTableToString(&rootBuffer, &tablesBuffer, common.TomlKeyValue{ Value: keyValues })
+
+	if rootBuffer.Len() > 0 && tablesBuffer.Len() > 0 {
+		rootBuffer.WriteString("\n")
+	}
+	return rootBuffer.String() + tablesBuffer.String()
+}
+
+func translateTableToString(rootBuffer, tablesBuffer *bytes.Buffer, table common.TomlKeyValue) {
+	var sectionBuffer bytes.Buffer
+	tableKey := table.Key
+	tablePrefix := tableKey + "."
+	keyValues := table.Value.([]common.TomlKeyValue)
+
+	for _, kv := range keyValues {
+		if kv.IsTable() {
+			continue
+		}
+		if len(tableKey) > 0 && strings.HasPrefix(kv.Key, tablePrefix) {
+			sectionBuffer.WriteString(translateKeyValueToString(strings.TrimPrefix(kv.Key, tablePrefix), kv))
+		} else {
+			rootBuffer.WriteString(translateKeyValueToString(kv.Key, kv))
+		}
+	}	// end -- for (scalars)
+
+	// a table with sub tables only needs no header (implicitly declared)
+	// unless there is a doc comment to place above the header
+	if sectionBuffer.Len() > 0 || (len(tableKey) > 0 && len(table.Comment) > 0) {
+		if tablesBuffer.Len() > 0 {
+			tablesBuffer.WriteString("\n")
+		}
+		tablesBuffer.WriteString(translateCommentToString(table.Comment))
+		tablesBuffer.WriteString(fmt.Sprintf("[%v]\n", tableKey))
+		tablesBuffer.Write(sectionBuffer.Bytes())
+	}
+	for _, kv := range keyValues {
+		if kv.IsTable() {
+			translateTableToString(rootBuffer, tablesBuffer, kv)
+		}
+	}	// end -- for (tables)
+}
+
+// translate a key value into a toml line preceded by its comment (if any).
+func translateKeyValueToString(key string, kv common.TomlKeyValue) string {
+	return translateCommentToString(kv.Comment) + translateScalarValueToString(key, kv.Value)
+}
+
+// translate a comment into toml comment lines e.g. "# Max concurrent workers";
+// every line of a multi-line comment is prefixed by "# ".
+func translateCommentToString(comment string) string {
+	if len(strings.TrimSpace(comment)) == 0 {
+		return ""
+	}
+	var bBuffer bytes.Buffer
+	for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			bBuffer.WriteString("# " + line + "\n")
+		} else {
+			bBuffer.WriteString("#\n")
+		}
+	}	// end -- for (comment lines)
+	return bBuffer.String()
+}
+
+// translate a scalar (or array) value into a toml line.
+func translateScalarValueToString(key string, value interface{}) string {
+	return fmt.Sprintf("%v = %v\n", key, formatScalarValue(value))
+}
+
+// format a scalar (or array) value into its toml representation.
+func formatScalarValue(value interface{}) string {
+	// check if it is array (has different format)
+	if sArrLine, bMatched := formatArrayValue(value); bMatched {
+		return sArrLine
+	}
+	switch fValue := value.(type) {
+	case float32:
+		return common.FormatFloatToString(float64(fValue), 32)
+	case float64:
+		return common.FormatFloatToString(fValue, 64)
+	}
+	return fmt.Sprintf("%v", value)
 }
 
-func translateArrayValueToStringFormat(value interface{}, key string) (string, bool) {
-	var cfgLine string
+// format the supported array types into the toml array format e.g. ["a","b"].
+func formatArrayValue(value interface{}) (string, bool) {
+	var sArrLine string
 	bMatched := false
 	sType := reflect.TypeOf(value).String()
 
 	if strings.Compare(sType, common.TypeArrayString) == 0 {
 		// cast
 		sArr := value.([]string)
-		sArrLine := "["
+		sArrLine = "["
 		for idx2, sVal := range sArr {
 			if idx2 > 0 {
 				sArrLine += ","
@@ -173,13 +498,12 @@ func translateArrayValueToStringFormat(value interface{}, key string) (string, b
 			sArrLine += "\"" + sVal + "\""
 		}
 		sArrLine += "]"
-		cfgLine = fmt.Sprintf("%v = %v\n", key, sArrLine)
 		bMatched = true
 
 	} else if strings.Compare(sType, common.TypeArrayInt) == 0 {
 		// cast
 		sArr := value.([]int)
-		sArrLine := "["
+		sArrLine = "["
 		for idx2, iVal := range sArr {
 			if idx2 > 0 {
 				sArrLine += ","
@@ -187,13 +511,12 @@ func translateArrayValueToStringFormat(value interface{}, key string) (string, b
 			sArrLine += fmt.Sprintf("%v", iVal)
 		}
 		sArrLine += "]"
-		cfgLine = fmt.Sprintf("%v = %v\n", key, sArrLine)
 		bMatched = true
 
 	} else if strings.Compare(sType, common.TypeArrayTime) == 0 {
 		// cast
 		sArr := value.([]time.Time)
-		sArrLine := "["
+		sArrLine = "["
 		for idx2, iVal := range sArr {
 			if idx2 > 0 {
 				sArrLine += ","
@@ -201,13 +524,12 @@ func translateArrayValueToStringFormat(value interface{}, key string) (string, b
 			sArrLine += "\"" + common.FormatTimeToString("", iVal) + "\""
 		}
 		sArrLine += "]"
-		cfgLine = fmt.Sprintf("%v = %v\n", key, sArrLine)
 		bMatched = true
 
 	} else if strings.Compare(sType, common.TypeArrayBool) == 0 {
 		// cast
 		sArr := value.([]bool)
-		sArrLine := "["
+		sArrLine = "["
 		for idx2, iVal := range sArr {
 			if idx2 > 0 {
 				sArrLine += ","
@@ -215,126 +537,83 @@ func translateArrayValueToStringFormat(value interface{}, key string) (string, b
 			sArrLine += fmt.Sprintf("%v", iVal)
 		}
 		sArrLine += "]"
-		cfgLine = fmt.Sprintf("%v = %v\n", key, sArrLine)
 		bMatched = true
 
 	} else if strings.Compare(sType, common.TypeArrayFloat32) == 0 {
 		// cast
 		sArr := value.([]float32)
-		sArrLine := "["
+		sArrLine = "["
 		for idx2, iVal := range sArr {
 			if idx2 > 0 {
 				sArrLine += ","
 			}
-			sArrLine += fmt.Sprintf("%v", iVal)
+			sArrLine += common.FormatFloatToString(float64(iVal), 32)
 		}
 		sArrLine += "]"
-		cfgLine = fmt.Sprintf("%v = %v\n", key, sArrLine)
 		bMatched = true
 
 	} else if strings.Compare(sType, common.TypeArrayFloat64) == 0 {
 		// cast
 		sArr := value.([]float64)
-		sArrLine := "["
+		sArrLine = "["
 		for idx2, iVal := range sArr {
 			if idx2 > 0 {
 				sArrLine += ","
 			}
-			sArrLine += fmt.Sprintf("%v", iVal)
+			sArrLine += common.FormatFloatToString(iVal, 64)
 		}
 		sArrLine += "]"
-		cfgLine = fmt.Sprintf("%v = %v\n", key, sArrLine)
 		bMatched = true
 
 	}
-	return cfgLine, bMatched
-}
-
-func translateNonPrimitiveValueToString(value interface{}) (string, bool, error) {
-	var err error
-	bMatched := false
-	sType := reflect.TypeOf(value).String()
-	var bBuffer bytes.Buffer	// create a bytes.Buffer for string concatenation
-
-	// is it a map?
-	if strings.Index(sType, common.TypePartialMap) != -1 {
-		if strings.Compare(common.TypeMapStringInterface, sType) == 0 {
-			// translation (we only handle map[string]interface{} type for now)
-			valueMap := value.(map[string]interface{})
-
-			for mKey, mVal := range valueMap {
-				cfgLine, bMatched2 := translateArrayValueToStringFormat(mVal, mKey)
-				if !bMatched2 {
-					// handle non array + non primitive (struct)
-					cfgLine, bMatched2, err = translateNonPrimitiveValueToString(mVal)
-					if err != nil {
-						return "", false, err
-					} else {
-						_, err := bBuffer.WriteString(cfgLine)
-						if err != nil {
-							return "", false, err
-						}
-					}
-					// primitive probably
-					if !bMatched2 {
-						_, err := bBuffer.WriteString(fmt.Sprintf("%v = %v\n", mKey, mVal))
-						if err != nil {
-							return "", false, err
-						}
-					}	// end -- if (primitive probably)
-				} else {
-					_, err := bBuffer.WriteString(cfgLine)
-					if err != nil {
-						return "", false, err
-					}
-				}	// end -- if (non array)
-			}	// end -- for (valueMap)
-			bMatched = true
-
-		} else {
-			panic(fmt.Sprintf("currently we only support map types of => %v\n", common.TypeMapStringInterface))
-		}
-	}	// end -- if (map type)
-	return bBuffer.String(), bMatched, nil
+	return sArrLine, bMatched
 }
 
 /* ------------------------------------ */
 /*	GETTERs based on key and dataType	*/
 /* ------------------------------------ */
 
-// deprecated method => get the string value based on a given key and
+// deprecated method (use Get instead) => get the string value based on a given key and
 // then extract the value corresponding to the key at runtime.
 func (t *TOMLConfigImpl) GetStringValueByKey(object interface{}, fieldName string) (bool, string) {
-	return common.GetStringValueByTomlField(object, t.StructType, fieldName)
+	return common.GetStringValueByTomlField(object, t.StructType, fieldName, t.NamingStrategy)
 }
-// deprecated method => get the int value based on a given key and
+// deprecated method (use Get instead) => get the int value based on a given key and
 // then extract the value corresponding to the key at runtime.
 func (t *TOMLConfigImpl) GetIntValueByKey(object interface{}, fieldName string) (bool, int64) {
-	return common.GetIntValueByTomlField(object, t.StructType, fieldName)
+	return common.GetIntValueByTomlField(object, t.StructType, fieldName, t.NamingStrategy)
 }
-// deprecated method => get the float value based on a given key and
+// deprecated method (use Get instead) => get the float value based on a given key and
 // then extract the value corresponding to the key at runtime.
 func (t *TOMLConfigImpl) GetFloatValueByKey(object interface{}, fieldName string) (bool, float64) {
-	return common.GetFloatValueByTomlField(object, t.StructType, fieldName)
+	return common.GetFloatValueByTomlField(object, t.StructType, fieldName, t.NamingStrategy)
 }
-// deprecated method => get the bool value based on a given key and
+// deprecated method (use Get instead) => get the bool value based on a given key and
 // then extract the value corresponding to the key at runtime.
 func (t *TOMLConfigImpl) GetBoolValueByKey(object interface{}, fieldName string) (bool, bool) {
-	return common.GetBoolValueByTomlField(object, t.StructType, fieldName)
+	return common.GetBoolValueByTomlField(object, t.StructType, fieldName, t.NamingStrategy)
 }
-// deprecated method => get the time.Time value based on a given key and
+// deprecated method (use Get instead) => get the time.Time value based on a given key and
 // then extract the value corresponding to the key at runtime.
 func (t *TOMLConfigImpl) GetTimeValueByKey(object interface{}, fieldName string) (bool, time.Time) {
-	return common.GetTimeValueByTomlField(object, t.StructType, fieldName)
+	return common.GetTimeValueByTomlField(object, t.StructType, fieldName, t.NamingStrategy)
 }
 
 
-// check if the field's value of the reference object equals to the given "value" (string)
+// deprecated method (use IsMatched instead) => check if the field's value of the
+// reference object equals to the given "value" (string)
 func (t *TOMLConfigImpl) IsFieldStringValueMatched(object interface{}, fieldName, value string) bool {
-	ok, sVal := common.GetStringValueByTomlField(object, t.StructType, fieldName)
+	ok, sVal := common.GetStringValueByTomlField(object, t.StructType, fieldName, t.NamingStrategy)
 
 	if ok && strings.Compare(sVal, value) == 0 {
 		return true
 	}
 	return false
 }
+
+// check if the reference object matches the predicate expression e.g.
+// "role in [admin, ops]", "workingHoursDay > 6 and hobbies contains soccer";
+// fields without a toml Tag are resolved by the NamingStrategy (check Match).
+func (t *TOMLConfigImpl) IsMatched(object interface{}, expression string) (bool, error) {
+	return Match(object, expression, t.NamingStrategy)
+}
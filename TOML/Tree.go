@@ -0,0 +1,549 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+package TOML
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// the TOML type of a Node.
+type NodeType int
+
+const (
+	// a table; either a [table] header or implied by a dotted key
+	NodeTable NodeType = iota
+	NodeString
+	NodeInteger
+	NodeFloat
+	NodeBool
+	NodeDatetime
+	NodeArray
+)
+
+// return the name of the node type (e.g. "integer").
+func (n NodeType) String() string {
+	switch n {
+	case NodeTable:
+		return "table"
+	case NodeString:
+		return "string"
+	case NodeInteger:
+		return "integer"
+	case NodeFloat:
+		return "float"
+	case NodeBool:
+		return "bool"
+	case NodeDatetime:
+		return "datetime"
+	case NodeArray:
+		return "array"
+	}
+	return fmt.Sprintf("NodeType(%v)", int(n))
+}
+
+// the position of a Node in the parsed source; 1-based, Line 0 => the
+// Node was not parsed (e.g. added by Tree.Set).
+type Position struct {
+	Line int
+	Column int
+}
+
+// return the position in the "line:column" format.
+func (p Position) String() string {
+	return fmt.Sprintf("%v:%v", p.Line, p.Column)
+}
+
+// a table or a key value of a Tree.
+type Node struct {
+	// the full dotted key e.g. "client.address.city"
+	Key string
+	// the TOML type of the value
+	Type NodeType
+	// the value => string, int64, float64, bool, time.Time or []interface{}
+	// (of these types); nil for tables
+	Value interface{}
+	// where the key (or [table] header) is declared; an implied table takes
+	// the position of the first key implying it
+	Position Position
+
+	// the table is implied by a dotted key / sub table (no [table] header)
+	implicit bool
+}
+
+// an untyped document tree of a TOML source; the tables and key values
+// could be inspected and modified without a Struct. Check ToStruct /
+// FromStruct to bridge to the reflection decoder / encoder.
+type Tree struct {
+	nodes map[string]*Node
+	// the keys of the nodes in declaration order
+	order []string
+}
+
+// create an empty Tree.
+func NewTree() *Tree {
+	return &Tree{ nodes: make(map[string]*Node) }
+}
+
+// parse the given toml file into a Tree.
+func ParseFile(filenameOrPath string) (*Tree, error) {
+	bBytes, err := common.LoadFile(filenameOrPath)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := Parse(bBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%v:%v", filenameOrPath, err)
+	}
+	return tree, nil
+}
+
+// parse the toml contents into a Tree; errors are prefixed by the line
+// number. Inline tables, arrays of tables and multi-line values are not
+// supported.
+func Parse(data []byte) (*Tree, error) {
+	tree := NewTree()
+	table := ""
+
+	for lineIdx, raw := range common.GetLinesFromByteArrayContent(data) {
+		line := strings.TrimRight(raw, "\r")
+		column := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+		line = strings.TrimSpace(line[:common.GetInlineCommentIndex(line, 0)])
+		position := Position{ Line: lineIdx + 1, Column: column }
+
+		if len(line) == 0 {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("%v: arrays of tables are not supported", position.Line)
+			}
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%v: invalid table header [%v]", position.Line, line)
+			}
+			table = strings.TrimSpace(line[1:len(line)-1])
+			if err := tree.addTable(table, position); err != nil {
+				return nil, fmt.Errorf("%v: %v", position.Line, err)
+			}
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || len(strings.TrimSpace(kv[0])) == 0 {
+			return nil, fmt.Errorf("%v: invalid key value [%v]", position.Line, line)
+		}
+		key := getDocumentKey(table, strings.TrimSpace(kv[0]))
+		node, err := parseNodeValue(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%v: key [%v] => %v", position.Line, key, err)
+		}
+		node.Key, node.Position = key, position
+		if err := tree.addNode(node); err != nil {
+			return nil, fmt.Errorf("%v: %v", position.Line, err)
+		}
+	}	// end -- for (lines)
+	return tree, nil
+}
+
+// create a Tree based on the Struct's values (same output as Marshal).
+func FromStruct(configObject interface{}) (*Tree, error) {
+	bBytes, err := Marshal(configObject)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bBytes)
+}
+
+// populate the given Struct pointer with the Tree's values (same as
+// Unmarshal); the hooks and validations are run as well.
+func (t *Tree) ToStruct(ptrConfigObject interface{}) error {
+	return Unmarshal(t.Bytes(), ptrConfigObject)
+}
+
+// check if the key (a table or a key value) exists.
+func (t *Tree) Has(key string) bool {
+	_, ok := t.nodes[key]
+	return ok
+}
+
+// return the value of the key; nil if the key does not exist or is a table.
+func (t *Tree) Get(key string) interface{} {
+	if node, ok := t.nodes[key]; ok {
+		return node.Value
+	}
+	return nil
+}
+
+// return the Node of the key (type and position included); nil if the key
+// does not exist.
+func (t *Tree) GetNode(key string) *Node {
+	return t.nodes[key]
+}
+
+// set the value of the key; missing tables of the key are created. The
+// value could be a string, bool, integer, float, time.Time, a slice of these
+// or a map (set as a table). Setting a key under a non table value or
+// replacing a table by a value is an error.
+func (t *Tree) Set(key string, value interface{}) error {
+	if len(strings.TrimSpace(key)) == 0 {
+		return fmt.Errorf("the key is empty")
+	}
+	val := reflect.ValueOf(value)
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.IsValid() && val.Kind() == reflect.Map {
+		return t.setTable(key, val)
+	}
+	node, err := newNodeByValue(val)
+	if err != nil {
+		return fmt.Errorf("key [%v] => %v", key, err)
+	}
+	if existing, ok := t.nodes[key]; ok {
+		if existing.Type == NodeTable {
+			return fmt.Errorf("key [%v] is a table", key)
+		}
+		existing.Type, existing.Value = node.Type, node.Value
+		return nil
+	}
+	node.Key = key
+	return t.addNode(node)
+}
+
+// set the map entries as the key values of the table (sorted by key).
+func (t *Tree) setTable(key string, mapVal reflect.Value) error {
+	if existing, ok := t.nodes[key]; ok && existing.Type != NodeTable {
+		return fmt.Errorf("key [%v] is not a table", key)
+	}
+	if !t.Has(key) {
+		if err := t.addTable(key, Position{}); err != nil {
+			return err
+		}
+	}
+	mapKeys := mapVal.MapKeys()
+	sort.Slice(mapKeys, func(i, j int) bool { return fmt.Sprintf("%v", mapKeys[i]) < fmt.Sprintf("%v", mapKeys[j]) })
+	for _, mapKey := range mapKeys {
+		if err := t.Set(fmt.Sprintf("%v.%v", key, mapKey.Interface()), mapVal.MapIndex(mapKey).Interface()); err != nil {
+			return err
+		}
+	}	// end -- for (map entries)
+	return nil
+}
+
+// remove the key; removing a table removes all its keys as well. False is
+// returned if the key does not exist.
+func (t *Tree) Delete(key string) bool {
+	if !t.Has(key) {
+		return false
+	}
+	order := []string{}
+	for _, nodeKey := range t.order {
+		if strings.Compare(nodeKey, key) == 0 || strings.HasPrefix(nodeKey, key + ".") {
+			delete(t.nodes, nodeKey)
+			continue
+		}
+		order = append(order, nodeKey)
+	}	// end -- for (nodes)
+	t.order = order
+	return true
+}
+
+// return the keys of the key values (tables excluded) in declaration order.
+func (t *Tree) Keys() []string {
+	keys := []string{}
+	for _, key := range t.order {
+		if t.nodes[key].Type != NodeTable {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// visit every table and key value in declaration order (a table before its
+// keys); an error returned by the function stops the walk.
+func (t *Tree) Walk(walkFunc func(node *Node) error) error {
+	for _, key := range append([]string{}, t.order...) {
+		if node, ok := t.nodes[key]; ok {
+			if err := walkFunc(node); err != nil {
+				return err
+			}
+		}
+	}	// end -- for (nodes)
+	return nil
+}
+
+// return the toml contents of the Tree; the root key values come first
+// followed by a [table] section per table (implied tables without key
+// values are omitted).
+func (t *Tree) Bytes() []byte {
+	var rootBuffer, tablesBuffer bytes.Buffer
+	sections := map[string]*bytes.Buffer{ "": &rootBuffer }
+	tables := []string{}
+
+	for _, key := range t.order {
+		node := t.nodes[key]
+		if node.Type == NodeTable {
+			sections[key] = &bytes.Buffer{}
+			tables = append(tables, key)
+			continue
+		}
+		table, localKey := "", key
+		if dotIdx := strings.LastIndex(key, "."); dotIdx != -1 {
+			table, localKey = key[:dotIdx], key[dotIdx+1:]
+		}
+		sections[table].WriteString(fmt.Sprintf("%v = %v\n", localKey, formatNodeValue(node.Value)))
+	}	// end -- for (nodes)
+
+	for _, table := range tables {
+		if sections[table].Len() == 0 && t.nodes[table].implicit {
+			// implicitly declared by its sub tables
+			continue
+		}
+		if rootBuffer.Len() > 0 || tablesBuffer.Len() > 0 {
+			tablesBuffer.WriteString("\n")
+		}
+		tablesBuffer.WriteString(fmt.Sprintf("[%v]\n", table))
+		tablesBuffer.Write(sections[table].Bytes())
+	}	// end -- for (tables)
+	return append(rootBuffer.Bytes(), tablesBuffer.Bytes()...)
+}
+
+// add the table (plus its missing parent tables); redefining a table
+// declared by a [table] header is an error.
+func (t *Tree) addTable(table string, position Position) error {
+	if existing, ok := t.nodes[table]; ok {
+		if existing.Type != NodeTable {
+			return fmt.Errorf("key [%v] is not a table", table)
+		}
+		if !existing.implicit {
+			return fmt.Errorf("table [%v] already defined at line %v", table, existing.Position.Line)
+		}
+		// an implied table declared by its header later
+		existing.Position, existing.implicit = position, false
+		return nil
+	}
+	if err := t.addParentTables(table, position); err != nil {
+		return err
+	}
+	t.nodes[table] = &Node{ Key: table, Type: NodeTable, Position: position }
+	t.order = append(t.order, table)
+	return nil
+}
+
+// add the key value (plus its missing parent tables).
+func (t *Tree) addNode(node *Node) error {
+	if existing, ok := t.nodes[node.Key]; ok {
+		return fmt.Errorf("key [%v] already defined at line %v", node.Key, existing.Position.Line)
+	}
+	if err := t.addParentTables(node.Key, node.Position); err != nil {
+		return err
+	}
+	t.nodes[node.Key] = node
+	t.order = append(t.order, node.Key)
+	return nil
+}
+
+// add the missing tables implied by the dotted key (e.g. "a.b.c" => a, a.b).
+func (t *Tree) addParentTables(key string, position Position) error {
+	parts := strings.Split(key, ".")
+	for idx := 1; idx < len(parts); idx++ {
+		table := strings.Join(parts[:idx], ".")
+		if existing, ok := t.nodes[table]; ok {
+			if existing.Type != NodeTable {
+				return fmt.Errorf("key [%v] is not a table", table)
+			}
+			continue
+		}
+		t.nodes[table] = &Node{ Key: table, Type: NodeTable, Position: position, implicit: true }
+		t.order = append(t.order, table)
+	}	// end -- for (parent tables)
+	return nil
+}
+
+// parse a toml value e.g. "text", 'literal', 42, 0x2A, 1_000.5, inf, true,
+// 1979-05-27T07:32:00Z or [1, 2].
+func parseNodeValue(value string) (*Node, error) {
+	switch {
+	case len(value) == 0:
+		return nil, fmt.Errorf("the value is empty")
+	case strings.HasPrefix(value, "{"):
+		return nil, fmt.Errorf("inline tables are not supported")
+	case strings.HasPrefix(value, "\"\"\"") || strings.HasPrefix(value, "'''"):
+		return nil, fmt.Errorf("multi-line strings are not supported")
+	case value[0] == '"':
+		sVal, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %v", value)
+		}
+		return &Node{ Type: NodeString, Value: sVal }, nil
+	case value[0] == '\'':
+		if len(value) < 2 || value[len(value)-1] != '\'' {
+			return nil, fmt.Errorf("invalid string %v", value)
+		}
+		return &Node{ Type: NodeString, Value: value[1:len(value)-1] }, nil
+	case value[0] == '[':
+		return parseArrayNodeValue(value)
+	case strings.Compare(value, "true") == 0 || strings.Compare(value, "false") == 0:
+		return &Node{ Type: NodeBool, Value: strings.Compare(value, "true") == 0 }, nil
+	}
+	if isTomlInteger(value) {
+		if iVal, err := strconv.ParseInt(value, 0, 64); err == nil {
+			return &Node{ Type: NodeInteger, Value: iVal }, nil
+		}
+	}
+	if tVal, _, err := common.ParseStringToTimeWithPatterns(
+		[]string{common.TimeDefault, common.TimeShortDateTime, common.TimeShortDate}, value); err == nil {
+		return &Node{ Type: NodeDatetime, Value: tVal }, nil
+	}
+	if fVal, err := common.ParseStringToFloat(value, 64); err == nil {
+		return &Node{ Type: NodeFloat, Value: fVal }, nil
+	}
+	return nil, fmt.Errorf("invalid value %v", value)
+}
+
+// check if the value is a toml integer e.g. 42, -17, 1_000, 0xDEAD, 0o755,
+// 0b1101; leading zeros are not allowed (strconv would read them as octal).
+func isTomlInteger(value string) bool {
+	digits := strings.TrimLeft(value, "+-")
+	if len(digits) == 0 || strings.ContainsAny(digits, ".eE") && !strings.HasPrefix(digits, "0x") {
+		return false
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		return strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0o") || strings.HasPrefix(digits, "0b")
+	}
+	return true
+}
+
+// parse a (single line) array e.g. [1, 2] or ["a", ["b"]].
+func parseArrayNodeValue(value string) (*Node, error) {
+	if value[len(value)-1] != ']' {
+		return nil, fmt.Errorf("multi-line arrays are not supported")
+	}
+	elements := []interface{}{}
+	for _, element := range splitArrayElements(value[1:len(value)-1]) {
+		if element = strings.TrimSpace(element); len(element) == 0 {
+			// trailing comma
+			continue
+		}
+		node, err := parseNodeValue(element)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, node.Value)
+	}	// end -- for (elements)
+	return &Node{ Type: NodeArray, Value: elements }, nil
+}
+
+// split the array's contents by the top level commas (commas within
+// strings and nested arrays are kept).
+func splitArrayElements(contents string) []string {
+	elements := []string{}
+	var quote byte
+	depth, start := 0, 0
+
+	for idx := 0; idx < len(contents); idx++ {
+		switch ch := contents[idx]; {
+		case quote == '"' && ch == '\\':
+			idx++
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote != 0:
+			// within a string
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case ch == ',' && depth == 0:
+			elements = append(elements, contents[start:idx])
+			start = idx + 1
+		}
+	}	// end -- for (characters)
+	return append(elements, contents[start:])
+}
+
+// create a key value Node based on the Go value (check Tree.Set).
+func newNodeByValue(val reflect.Value) (*Node, error) {
+	if !val.IsValid() {
+		return nil, fmt.Errorf("the value is nil")
+	}
+	if tVal, ok := val.Interface().(time.Time); ok {
+		return &Node{ Type: NodeDatetime, Value: tVal }, nil
+	}
+	switch val.Kind() {
+	case reflect.String:
+		return &Node{ Type: NodeString, Value: val.String() }, nil
+	case reflect.Bool:
+		return &Node{ Type: NodeBool, Value: val.Bool() }, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Node{ Type: NodeInteger, Value: val.Int() }, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%v overflows a toml integer", val.Uint())
+		}
+		return &Node{ Type: NodeInteger, Value: int64(val.Uint()) }, nil
+	case reflect.Float32:
+		// keep the float32's shortest representation (e.g. 12.3)
+		fVal, _ := strconv.ParseFloat(strconv.FormatFloat(val.Float(), 'g', -1, 32), 64)
+		return &Node{ Type: NodeFloat, Value: fVal }, nil
+	case reflect.Float64:
+		return &Node{ Type: NodeFloat, Value: val.Float() }, nil
+	case reflect.Slice, reflect.Array:
+		elements := []interface{}{}
+		for idx := 0; idx < val.Len(); idx++ {
+			elemVal := val.Index(idx)
+			for (elemVal.Kind() == reflect.Interface || elemVal.Kind() == reflect.Ptr) && !elemVal.IsNil() {
+				elemVal = elemVal.Elem()
+			}
+			node, err := newNodeByValue(elemVal)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, node.Value)
+		}	// end -- for (elements)
+		return &Node{ Type: NodeArray, Value: elements }, nil
+	}
+	return nil, fmt.Errorf("unsupported value type [%v]", val.Type())
+}
+
+// format a Node's value into its toml representation.
+func formatNodeValue(value interface{}) string {
+	switch nValue := value.(type) {
+	case string:
+		return strconv.Quote(nValue)
+	case float64:
+		return common.FormatFloatToString(nValue, 64)
+	case time.Time:
+		return nValue.Format(time.RFC3339Nano)
+	case []interface{}:
+		sElements := make([]string, len(nValue))
+		for idx, element := range nValue {
+			sElements[idx] = formatNodeValue(element)
+		}
+		return "[" + strings.Join(sElements, ",") + "]"
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package TOML
+
+import (
+	"errors"
+
+	"github.com/quoeamaster/CFactor/common"
+)
+
+// hot reload of a Store on modifications of its config file. The file is
+// reloaded and validated by the Store; if the new contents fail to parse
+// or validate, the previous (last known good) snapshot keeps being served
+// and the error is reported through OnError.
+type Watcher[T any] struct {
+	Store *Store[T]
+
+	// detection of the file modifications (Debounce, PollInterval and
+	// Polling could be set before Start)
+	File *common.FileWatcher
+
+	// optional callback invoked with the new snapshot after a reload
+	OnReload func(config *T)
+
+	// optional callback invoked if a reload failed (the previous snapshot
+	// is kept)
+	OnError func(err error)
+}
+
+// create a Watcher of the Store's config file; the Store's Config must be
+// a *TOMLConfigImpl (the file is its Name).
+func NewWatcher[T any](store *Store[T]) (*Watcher[T], error) {
+	if store == nil {
+		return nil, errors.New("no Store available for the Watcher")
+	}
+	config, ok := store.Config.(*TOMLConfigImpl)
+	if !ok || len(config.Name) == 0 {
+		return nil, errors.New("the Store's Config must be a *TOMLConfigImpl with a Name")
+	}
+	return &Watcher[T]{ Store: store, File: common.NewFileWatcher(config.Name) }, nil
+}
+
+// start watching the config file; the Store is loaded first if it has no
+// snapshot yet (an error is returned if this initial load fails as there
+// is nothing to serve). Callbacks are invoked on the watcher's goroutine.
+func (w *Watcher[T]) Start() error {
+	if w.Store.Get() == nil {
+		if _, err := w.Store.Load(); err != nil {
+			return err
+		}
+	}
+	return w.File.Start(w.reload)
+}
+
+// stop watching the config file; the Store keeps its current snapshot.
+func (w *Watcher[T]) Stop() {
+	w.File.Stop()
+}
+
+// return the current snapshot of the Store.
+func (w *Watcher[T]) Get() *T {
+	return w.Store.Get()
+}
+
+func (w *Watcher[T]) reload() {
+	config, err := w.Store.Load()
+	if err != nil {
+		if w.OnError != nil {
+			w.OnError(err)
+		}
+		return
+	}
+	if w.OnReload != nil {
+		w.OnReload(config)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"io/ioutil"
 	"strings"
 	"os"
+	"path/filepath"
+	"fmt"
 )
 
 
@@ -42,6 +44,9 @@ func GetLinesFromByteArrayContent(data []byte) ([]string) {
 }
 
 // function to create a file. Returns a file reference (*os.File)
+//
+// the file is truncated right away; use WriteFileAtomic to replace the
+// contents of an existing file safely.
 func CreateFile(filename string) (*os.File) {
 	if !IsStringEmptyOrNil(filename) {
 		filePtr, err := os.Create(filename)
@@ -53,6 +58,131 @@ func CreateFile(filename string) (*os.File) {
 	return nil
 }
 
+// function to replace the contents of a file in a crash-safe way; the data
+// is written to a temp file in the same folder, synced to disk and renamed
+// over the target. Readers see either the old or the new contents, never a
+// half written file. The mode and owner of an existing target are kept
+// (0644 for a new file); if the process may not change the owner (non
+// root), the replaced file is owned by the writer. A symlink is resolved
+// and its target is replaced.
+//
+// "backups" is the number of rotated copies of the previous contents to keep
+// (filename.bak.1 being the newest up to filename.bak.N); 0 => no backups.
+func WriteFileAtomic(filename string, data []byte, backups int) (err error) {
+	if IsStringEmptyOrNil(filename) {
+		return fmt.Errorf("the filename is empty")
+	}
+	// replace the real file instead of a symlink pointing to it
+	if realName, linkErr := filepath.EvalSymlinks(filename); linkErr == nil {
+		filename = realName
+	}
+	mode := os.FileMode(0644)
+	info, statErr := os.Stat(filename)
+	if statErr == nil {
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("[%v] is not a regular file", filename)
+		}
+		mode = info.Mode().Perm()
+	} else if !os.IsNotExist(statErr) {
+		return statErr
+	}
+
+	dir, base := filepath.Split(filename)
+	if len(dir) == 0 {
+		dir = "."
+	}
+	tmpName, err := writeTempFile(dir, base, data, mode)
+	if err != nil {
+		return err
+	}
+	// sort of finally clause; the temp file is removed on any failure
+	defer func() {
+		if err != nil {
+			os.Remove(tmpName)
+		}
+	}()
+
+	if info != nil {
+		if err = chownLike(tmpName, info); err != nil {
+			return err
+		}
+		if err = rotateBackupFiles(filename, mode, backups); err != nil {
+			return err
+		}
+	}
+	if err = os.Rename(tmpName, filename); err != nil {
+		return err
+	}
+	// the rename itself is only durable once the folder is synced
+	return syncDir(dir)
+}
+
+// write the data into a new temp file (synced to disk) in the given folder;
+// returns the temp file's name. The temp file is removed on failures.
+func writeTempFile(dir, base string, data []byte, mode os.FileMode) (string, error) {
+	tmpFile, err := ioutil.TempFile(dir, "." + base + ".tmp")
+	if err != nil {
+		return "", err
+	}
+	tmpName := tmpFile.Name()
+
+	if _, err = tmpFile.Write(data); err == nil {
+		err = tmpFile.Sync()
+	}
+	if cErr := tmpFile.Close(); err == nil {
+		err = cErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpName, mode)
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		return "", err
+	}
+	return tmpName, nil
+}
+
+// shift filename.bak.1 ... filename.bak.(N-1) by one and copy the current
+// contents of the file to filename.bak.1; the oldest copy is dropped. The
+// copy is written through a synced temp file plus rename (same as
+// WriteFileAtomic), hence a crash never leaves a truncated backup behind.
+func rotateBackupFiles(filename string, mode os.FileMode, backups int) error {
+	if backups <= 0 {
+		return nil
+	}
+	for idx := backups - 1; idx >= 1; idx-- {
+		backupName := GetBackupFilename(filename, idx)
+		if _, err := os.Stat(backupName); err == nil {
+			if err := os.Rename(backupName, GetBackupFilename(filename, idx+1)); err != nil {
+				return err
+			}
+		}
+	}	// end -- for (backups)
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	backupName := GetBackupFilename(filename, 1)
+	dir, base := filepath.Split(backupName)
+	if len(dir) == 0 {
+		dir = "."
+	}
+	tmpName, err := writeTempFile(dir, base, data, mode)
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmpName, backupName); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+// return the name of the "idx"-th backup of the given file (e.g. app.toml.bak.1).
+func GetBackupFilename(filename string, idx int) string {
+	return fmt.Sprintf("%v.bak.%v", filename, idx)
+}
+
 /**
  *	helper method to remove a file by the "filename"
  *
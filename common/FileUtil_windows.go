@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common
+
+import (
+	"os"
+)
+
+// file ownership is not applicable on windows.
+func chownLike(filename string, info os.FileInfo) error {
+	return nil
+}
+
+// folders could not be synced on windows; the rename is flushed by the OS.
+func syncDir(dir string) error {
+	return nil
+}
@@ -0,0 +1,255 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// package containing common functions and features for CFactor to work smoothly.
+// FileWatcher contains the detection of file modifications.
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// the default quiet period before a burst of file events is reported
+const DefaultWatchDebounce = 100 * time.Millisecond
+// the default interval of checking the file when polling
+const DefaultWatchPollInterval = time.Second
+
+// watcher reporting the modifications of a file. The file's folder is
+// watched (inotify on linux) hence editors saving through a temp file plus
+// rename (or deleting and re-creating the file) and symlink swaps (e.g.
+// kubernetes ConfigMaps) are detected; polling is used on the other
+// platforms or if the notification could not be setup. Events are debounced
+// and reported only if the file's contents really changed.
+type FileWatcher struct {
+	Filename string
+
+	// the quiet period before a burst of events is reported; 0 => DefaultWatchDebounce
+	Debounce time.Duration
+
+	// the interval of checking the file when polling; 0 => DefaultWatchPollInterval
+	PollInterval time.Duration
+
+	// always poll the file instead of using the OS notification (check
+	// IsPolling for the mode in use)
+	Polling bool
+
+	// true if the file is polled (Polling set, or fallback as the OS
+	// notification is not available / failed)
+	polling atomic.Bool
+	// checksum of the contents last reported
+	checksum []byte
+	notifier fileNotifier
+	stop chan struct{}
+	done chan struct{}
+	lock sync.Mutex
+}
+
+// platform specific notification of the events of a folder
+type fileNotifier interface {
+	// blocks until events are available; returns the names of the entries changed
+	read() ([]string, error)
+	close() error
+}
+
+// create a FileWatcher for the given file.
+func NewFileWatcher(filename string) *FileWatcher {
+	return &FileWatcher{ Filename: filename }
+}
+
+// start watching the file; "onChange" is invoked (on the watcher's
+// goroutine) every time the file's contents changed. A missing file is not
+// an error (e.g. in the middle of a replace); the change is reported once
+// the file is back. Returns an error if the watcher is started already.
+func (w *FileWatcher) Start(onChange func()) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.stop != nil {
+		return errors.New("the FileWatcher is started already")
+	}
+	filename, err := filepath.Abs(w.Filename)
+	if err != nil {
+		return err
+	}
+	if w.Debounce <= 0 {
+		w.Debounce = DefaultWatchDebounce
+	}
+	if w.PollInterval <= 0 {
+		w.PollInterval = DefaultWatchPollInterval
+	}
+	w.checksum = getFileChecksum(filename)
+
+	var events chan []string
+	w.polling.Store(true)
+	if !w.Polling {
+		// fallback to polling if the notification could not be setup
+		if notifier, nErr := newFileNotifier(filepath.Dir(filename)); nErr == nil {
+			w.notifier = notifier
+			events = make(chan []string)
+			w.polling.Store(false)
+		}
+	}
+	w.stop = make(chan struct{})
+	if events != nil {
+		go readFileNotifier(w.notifier, events, w.stop)
+	}
+	w.done = make(chan struct{})
+	go w.run(filename, events, onChange)
+	return nil
+}
+
+// check if the file is polled instead of being notified by the OS (Polling
+// set, notification not available or failed while watching).
+func (w *FileWatcher) IsPolling() bool {
+	return w.polling.Load()
+}
+
+// stop watching the file; waits until the watcher's goroutine exits (hence
+// must not be called within "onChange"). "onChange" is not invoked once
+// Stop was called. A no-op if not started.
+func (w *FileWatcher) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.stop == nil {
+		return
+	}
+	close(w.stop)
+	if w.notifier != nil {
+		w.notifier.close()
+		w.notifier = nil
+	}
+	<-w.done
+	w.stop = nil
+	w.done = nil
+}
+
+// forward the names of the notified entries until the notifier is closed.
+func readFileNotifier(notifier fileNotifier, events chan<- []string, stop <-chan struct{}) {
+	defer close(events)
+	for {
+		names, err := notifier.read()
+		if err != nil {
+			return
+		}
+		select {
+		case events <- names:
+		case <-stop:
+			return
+		}
+	}	// end -- for (events)
+}
+
+func (w *FileWatcher) run(filename string, events <-chan []string, onChange func()) {
+	defer close(w.done)
+
+	var ticker <-chan time.Time
+	var pollTicker *time.Ticker
+	if events == nil {
+		pollTicker = time.NewTicker(w.PollInterval)
+		ticker = pollTicker.C
+	}
+	defer func() {
+		if pollTicker != nil {
+			pollTicker.Stop()
+		}
+	}()
+	debounceTimer := time.NewTimer(w.Debounce)
+	debounceTimer.Stop()
+	defer debounceTimer.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case names, ok := <-events:
+			if !ok {
+				if w.isStopped() {
+					// closed by Stop
+					return
+				}
+				// notifier failed; fallback to polling
+				events = nil
+				pollTicker = time.NewTicker(w.PollInterval)
+				ticker = pollTicker.C
+				w.polling.Store(true)
+				w.checkFile(filename, onChange)
+				continue
+			}
+			if isFileEventRelevant(filename, names) {
+				debounceTimer.Reset(w.Debounce)
+			}
+		case <-ticker:
+			w.checkFile(filename, onChange)
+		case <-debounceTimer.C:
+			w.checkFile(filename, onChange)
+		}
+	}	// end -- for (select)
+}
+
+// check if Stop was called (the watcher's goroutine is exiting).
+func (w *FileWatcher) isStopped() bool {
+	select {
+	case <-w.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// report the change if the file's contents differ from the last reported;
+// nothing is reported once Stop was called.
+func (w *FileWatcher) checkFile(filename string, onChange func()) {
+	checksum := getFileChecksum(filename)
+	if checksum == nil || bytes.Equal(checksum, w.checksum) || w.isStopped() {
+		return
+	}
+	w.checksum = checksum
+	onChange()
+}
+
+// an event is relevant if it is about the file itself; any event counts
+// for a symlinked file (the target could be swapped elsewhere e.g. the
+// "..data" folder of a kubernetes ConfigMap).
+func isFileEventRelevant(filename string, names []string) bool {
+	if info, err := os.Lstat(filename); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return true
+	}
+	base := filepath.Base(filename)
+	for _, name := range names {
+		if name == base {
+			return true
+		}
+	}	// end -- for (names)
+	return false
+}
+
+// return the checksum of the file's contents; nil if the file is not readable.
+func getFileChecksum(filename string) []byte {
+	data, err := LoadFile(filename)
+	if err != nil {
+		return nil
+	}
+	checksum := sha256.Sum256(data)
+	return checksum[:]
+}
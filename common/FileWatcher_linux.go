@@ -0,0 +1,77 @@
+//go:build linux
+// +build linux
+
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// the folder events relevant to a file being written, replaced or removed
+const inotifyFileEvents = syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_ATTRIB
+
+// inotify based notification of a folder's events.
+type inotifyNotifier struct {
+	file *os.File
+	buffer []byte
+}
+
+// watch the folder through inotify.
+func newFileNotifier(dir string) (fileNotifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	if _, err := syscall.InotifyAddWatch(fd, dir, inotifyFileEvents); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("inotify_add_watch", err)
+	}
+	// a non blocking fd is handled by the runtime poller; closing it unblocks read
+	return &inotifyNotifier{
+		file: os.NewFile(uintptr(fd), "inotify"),
+		buffer: make([]byte, 64 * (syscall.SizeofInotifyEvent + syscall.NAME_MAX + 1)),
+	}, nil
+}
+
+func (n *inotifyNotifier) read() ([]string, error) {
+	count, err := n.file.Read(n.buffer)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for offset := 0; offset + syscall.SizeofInotifyEvent <= count; {
+		event := (*syscall.InotifyEvent)(unsafe.Pointer(&n.buffer[offset]))
+		start := offset + syscall.SizeofInotifyEvent
+		end := start + int(event.Len)
+		if end > count {
+			break
+		}
+		names = append(names, string(bytes.TrimRight(n.buffer[start:end], "\x00")))
+		offset = end
+	}	// end -- for (events)
+	return names, nil
+}
+
+func (n *inotifyNotifier) close() error {
+	return n.file.Close()
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// LifeCycleUtil contains the lifecycle hooks related functions.
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"github.com/quoeamaster/CFactor/interfaces"
+)
+
+// name of the "before load" lifecycle hook
+const HookBeforeLoad = "BeforeLoad"
+// name of the "after load" lifecycle hook
+const HookAfterLoad = "AfterLoad"
+// name of the "before save" lifecycle hook
+const HookBeforeSave = "BeforeSave"
+// name of the "after save" lifecycle hook
+const HookAfterSave = "AfterSave"
+
+// invoke the BeforeLoad hooks (top-down) on the object and its child Structs.
+func InvokeBeforeLoadHooks(object interface{}) error {
+	return invokeLifeCycleHooks(object, HookBeforeLoad, true)
+}
+
+// invoke the AfterLoad hooks (bottom-up) on the object and its child Structs.
+func InvokeAfterLoadHooks(object interface{}) error {
+	return invokeLifeCycleHooks(object, HookAfterLoad, false)
+}
+
+// invoke the BeforeSave hooks (top-down) on the object and its child Structs.
+func InvokeBeforeSaveHooks(object interface{}) error {
+	return invokeLifeCycleHooks(object, HookBeforeSave, true)
+}
+
+// invoke the AfterSave hooks (bottom-up) on the object and its child Structs.
+func InvokeAfterSaveHooks(object interface{}) error {
+	return invokeLifeCycleHooks(object, HookAfterSave, false)
+}
+
+func invokeLifeCycleHooks(object interface{}, hook string, topDown bool) error {
+	if !IsValidPointer(object) {
+		return nil
+	}
+	objVal := reflect.ValueOf(object)
+	if objVal.Kind() != reflect.Ptr {
+		// hooks could only be invoked on addressable Struct(s)
+		return fmt.Errorf("lifecycle hook [%v] requires a pointer to the Struct; given [%v]", hook, objVal.Type())
+	}
+	return invokeLifeCycleHooksOnStruct(objVal.Elem(), hook, topDown)
+}
+
+func invokeLifeCycleHooksOnStruct(objVal reflect.Value, hook string, topDown bool) error {
+	if objVal.Kind() != reflect.Struct {
+		return nil
+	}
+	if topDown {
+		if err := invokeLifeCycleHook(objVal, hook); err != nil {
+			return err
+		}
+	}
+	objType := objVal.Type()
+	for i := 0; i < objType.NumField(); i++ {
+		if strings.Compare(objType.Field(i).Tag.Get(TagAdditional), ConfigTypeParent) == 0 {
+			if err := invokeLifeCycleHooksOnStruct(objVal.Field(i), hook, topDown); err != nil {
+				return err
+			}
+		}
+	}	// end -- for (fields)
+	if !topDown {
+		return invokeLifeCycleHook(objVal, hook)
+	}
+	return nil
+}
+
+// invoke the given hook on the Struct (if the corresponding interface is implemented).
+func invokeLifeCycleHook(objVal reflect.Value, hook string) error {
+	var err error
+	object := getHookTarget(objVal)
+
+	switch hook {
+	case HookBeforeLoad:
+		if h, ok := object.(interfaces.IConfigBeforeLoad); ok {
+			err = h.BeforeLoad()
+		}
+	case HookAfterLoad:
+		if h, ok := object.(interfaces.IConfigAfterLoad); ok {
+			err = h.AfterLoad()
+		}
+	case HookBeforeSave:
+		if h, ok := object.(interfaces.IConfigBeforeSave); ok {
+			err = h.BeforeSave()
+		}
+	case HookAfterSave:
+		if h, ok := object.(interfaces.IConfigAfterSave); ok {
+			err = h.AfterSave()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("lifecycle hook [%v] failed on [%v] => %v", hook, objVal.Type(), err)
+	}
+	return nil
+}
+
+// return the object for the hook invocation; a pointer to the Struct is
+// returned if possible, so that hooks with a pointer receiver are found.
+func getHookTarget(objVal reflect.Value) interface{} {
+	if objVal.CanAddr() {
+		return objVal.Addr().Interface()
+	}
+	return objVal.Interface()
+}
@@ -0,0 +1,232 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// MetaData contains the decode meta data related functions.
+package common
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// struct wrapping the meta data gathered during a config loading operation;
+// answers questions like "was this key explicitly set in the config file
+// or is the field's value simply the zero value?"
+type DecodeMetaData struct {
+	// keys found in the config file AND populated into a Struct field;
+	// the value is the line number (1-based) of the key within the file
+	Defined map[string]int
+
+	// keys found in the config file BUT no Struct field matched;
+	// the value is the line number (1-based) of the key within the file
+	Undecoded map[string]int
+
+	// keys declared by the Struct (through the toml Tag) BUT not found in
+	// the config file, hence the corresponding fields keep their default values
+	Defaulted []string
+
+	// deprecated keys (aliases or fields with the deprecated Tag) found in
+	// the config file; in the order of their source lines
+	Deprecated []DeprecationWarning
+}
+
+// struct describing a deprecated key found in the config file.
+type DeprecationWarning struct {
+	// the config file (filled in by the config implementation e.g. TOMLConfigImpl)
+	File string
+	// the source line (1-based) of the deprecated key
+	Line int
+	// the deprecated key found in the config file
+	Key string
+	// the key to use instead (empty if the key is simply deprecated)
+	NewKey string
+	// the migration hint of the deprecated Tag (e.g. "use work.hoursPerDay")
+	Message string
+}
+
+// return a description of the warning
+// e.g. "config.toml:3: key [workingHoursDay] is deprecated; use work.hoursPerDay".
+func (w DeprecationWarning) String() string {
+	return fmt.Sprintf("%v:%v: key [%v] is deprecated; %v", w.File, w.Line, w.Key, w.Message)
+}
+
+// create a new DecodeMetaData instance.
+func NewDecodeMetaData() DecodeMetaData {
+	metaData := DecodeMetaData{
+		Defined: make(map[string]int),
+		Undecoded: make(map[string]int),
+		Defaulted: []string{},
+		Deprecated: []DeprecationWarning{},
+	}
+	return metaData
+}
+
+// check if the given key (e.g. "author.age") was explicitly set in the config file.
+func (m *DecodeMetaData) IsDefined(key string) bool {
+	_, ok := m.Defined[key]
+	return ok
+}
+
+// check if the given key was found in the config file but not decoded into
+// any Struct field (probably a typo or an obsolete key).
+func (m *DecodeMetaData) IsUndecoded(key string) bool {
+	_, ok := m.Undecoded[key]
+	return ok
+}
+
+// check if the given key is declared by the Struct but missing in the config
+// file (hence the field is left with its default value).
+func (m *DecodeMetaData) IsDefaulted(key string) bool {
+	for _, k := range m.Defaulted {
+		if strings.Compare(k, key) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// return the source line (1-based) of the given key; 0 is returned if the
+// key is not available in the config file.
+func (m *DecodeMetaData) GetLine(key string) int {
+	if line, ok := m.Defined[key]; ok {
+		return line
+	}
+	if line, ok := m.Undecoded[key]; ok {
+		return line
+	}
+	return 0
+}
+
+// return the defined keys sorted by their source line.
+func (m *DecodeMetaData) DefinedKeys() []string {
+	return getKeysSortedByLine(m.Defined)
+}
+
+// return the undecoded keys sorted by their source line.
+func (m *DecodeMetaData) UndecodedKeys() []string {
+	return getKeysSortedByLine(m.Undecoded)
+}
+
+func getKeysSortedByLine(keyLineMap map[string]int) []string {
+	keys := make([]string, 0, len(keyLineMap))
+	for k := range keyLineMap {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keyLineMap[keys[i]] == keyLineMap[keys[j]] {
+			return keys[i] < keys[j]
+		}
+		return keyLineMap[keys[i]] < keyLineMap[keys[j]]
+	})
+	return keys
+}
+
+// record the key found at the given source line. A key matched through an
+// alias is recorded under the field's key; deprecated keys are recorded
+// into Deprecated as well.
+func (m *DecodeMetaData) setDefinedKey(key string, line int, tag TagStructure) {
+	definedKey := key
+	isAlias := len(tag.Field) > 0 && !tag.IsKeyMatched(key) && tag.IsAliasMatched(key)
+	if isAlias {
+		definedKey = tag.Field
+	}
+	m.Defined[definedKey] = line
+
+	if isAlias || (len(tag.Aliases) == 0 && len(tag.Deprecated) > 0) {
+		warning := DeprecationWarning{ Line: line, Key: key, Message: tag.Deprecated }
+		if isAlias {
+			warning.NewKey = tag.Field
+			if len(warning.Message) == 0 {
+				warning.Message = fmt.Sprintf("use %v", tag.Field)
+			}
+		}
+		m.Deprecated = append(m.Deprecated, warning)
+	}
+}
+
+// update the Defaulted keys; any key declared by the given Struct type
+// but not yet Defined would be treated as defaulted.
+func (m *DecodeMetaData) setDefaultedKeys(objectType reflect.Type, strategy KeyNamingStrategy) {
+	m.Defaulted = []string{}
+	for _, key := range GetTomlKeysByStrategy(objectType, strategy) {
+		if !m.isDefinedByStrategy(key, strategy) {
+			m.Defaulted = append(m.Defaulted, key)
+		}
+	}
+}
+
+// check if the given key is defined; keys are compared case-insensitively
+// for the NamingCaseInsensitive strategy.
+func (m *DecodeMetaData) isDefinedByStrategy(key string, strategy KeyNamingStrategy) bool {
+	if strategy != NamingCaseInsensitive {
+		return m.IsDefined(key)
+	}
+	for k := range m.Defined {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// return all the toml keys declared by the given Struct type; child Struct(s)
+// (fields with additional:"parent") and inline Struct(s) are traversed
+// recursively, hence only the "leaf" keys are returned (in Struct field order).
+// Skipped fields (toml:"-") are excluded.
+func GetTomlKeysByType(objectType reflect.Type) []string {
+	return GetTomlKeysByStrategy(objectType, NamingNone)
+}
+
+// return all the toml keys declared by the given Struct type (same as
+// GetTomlKeysByType); fields without a toml Tag are keyed by the key derived
+// through the given naming "strategy".
+func GetTomlKeysByStrategy(objectType reflect.Type, strategy KeyNamingStrategy) []string {
+	return getTomlKeysByType(objectType, "", strategy)
+}
+
+func getTomlKeysByType(objectType reflect.Type, prefix string, strategy KeyNamingStrategy) []string {
+	keys := []string{}
+	objectType = getIndirectType(objectType)
+
+	if objectType == nil || objectType.Kind() != reflect.Struct {
+		return keys
+	}
+	for i := 0; i < objectType.NumField(); i++ {
+		field := objectType.Field(i)
+		tag := NewTagStructureByStrategy(field, prefix, strategy)
+
+		if tag.Skip {
+			continue
+		} else if tag.IsParent() {
+			keys = append(keys, getTomlKeysByType(field.Type, tag.Field, strategy)...)
+		} else if tag.Inline && field.Type.Kind() == reflect.Struct {
+			keys = append(keys, getTomlKeysByType(field.Type, prefix, strategy)...)
+		} else if len(tag.Field) > 0 {
+			keys = append(keys, tag.Field)
+		}
+	}	// end -- for (fields)
+	return keys
+}
+
+// return the Type pointed by the given pointer Type (if applicable).
+func getIndirectType(objectType reflect.Type) reflect.Type {
+	if objectType != nil && objectType.Kind() == reflect.Ptr {
+		return objectType.Elem()
+	}
+	return objectType
+}
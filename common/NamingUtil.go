@@ -0,0 +1,154 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// NamingUtil contains the key naming strategy related functions.
+package common
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// strategy to derive a toml key from a Struct field's name when the field
+// has no toml Tag (or the Tag carries options only e.g. toml:",omitempty").
+type KeyNamingStrategy int
+
+const (
+	// fields without a toml Tag are ignored (default)
+	NamingNone KeyNamingStrategy = iota
+	// the field's name as-is => "FirstName"
+	NamingExact
+	// camelCase => "firstName", "HTTPPort" becomes "httpPort"
+	NamingCamelCase
+	// snake_case => "first_name"
+	NamingSnakeCase
+	// kebab-case => "first-name"
+	NamingKebabCase
+	// the field's name matched case-insensitively => "firstname", "FIRSTNAME";
+	// saved as the field's name as-is
+	NamingCaseInsensitive
+)
+
+// return the strategy's name (e.g. "snake_case").
+func (s KeyNamingStrategy) String() string {
+	switch s {
+	case NamingExact:
+		return "exact"
+	case NamingCamelCase:
+		return "camelCase"
+	case NamingSnakeCase:
+		return "snake_case"
+	case NamingKebabCase:
+		return "kebab-case"
+	case NamingCaseInsensitive:
+		return "case-insensitive"
+	}
+	return "none"
+}
+
+// return the toml key of the given field name based on the strategy; an
+// empty string is returned for NamingNone. The "prefix" is the key of the
+// parent Struct (if any) e.g. "author" + "FirstName" => "author.firstName".
+func (s KeyNamingStrategy) GetKeyByFieldName(prefix, fieldName string) string {
+	key := ""
+	switch s {
+	case NamingExact, NamingCaseInsensitive:
+		key = fieldName
+	case NamingCamelCase:
+		words := splitFieldNameIntoWords(fieldName)
+		for idx, word := range words {
+			if idx == 0 {
+				key += strings.ToLower(word)
+			} else {
+				key += strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+			}
+		}
+	case NamingSnakeCase:
+		key = strings.ToLower(strings.Join(splitFieldNameIntoWords(fieldName), "_"))
+	case NamingKebabCase:
+		key = strings.ToLower(strings.Join(splitFieldNameIntoWords(fieldName), "-"))
+	default:
+		return ""
+	}
+	if len(prefix) > 0 && len(key) > 0 {
+		key = prefix + "." + key
+	}
+	return key
+}
+
+// split the field name into words by the case changes;
+// "HTTPServerPort2" => "HTTP", "Server", "Port2".
+func splitFieldNameIntoWords(fieldName string) []string {
+	words := []string{}
+	runes := []rune(fieldName)
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		// "aB" or "a1B" => new word; "ABc" => new word starts at "B"
+		if !unicode.IsUpper(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}	// end -- for (runes)
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// create a TagStructure based on the given Struct field's Tag (check
+// NewTagStructure); if the Tag provides no key, the key is derived from the
+// field's name through the naming strategy. Unexported fields and fields of
+// unsupported types are never named by the strategy.
+func NewTagStructureByStrategy(field reflect.StructField, prefix string, strategy KeyNamingStrategy) TagStructure {
+	tag := NewTagStructure(field)
+
+	if tag.Skip || len(tag.Field) > 0 || tag.Inline || len(field.PkgPath) > 0 {
+		return tag
+	}
+	if !tag.IsParent() && !isNamingSupportedType(field.Type) {
+		return tag
+	}
+	tag.Field = strategy.GetKeyByFieldName(prefix, field.Name)
+	tag.CaseInsensitive = strategy == NamingCaseInsensitive
+	return tag
+}
+
+// check if the given key matches the field's key.
+func (t TagStructure) IsKeyMatched(key string) bool {
+	if len(t.Field) == 0 {
+		return false
+	}
+	if t.CaseInsensitive {
+		return strings.EqualFold(t.Field, key)
+	}
+	return strings.Compare(t.Field, key) == 0
+}
+
+// check if the given type could be populated / saved (check setValueByDataType).
+func isNamingSupportedType(fieldType reflect.Type) bool {
+	switch fieldType.String() {
+	case TypeString, TypeInt, TypeBool, TypeFloat32, TypeFloat64, TypeTime,
+		TypeArrayString, TypeArrayInt, TypeArrayBool, TypeArrayFloat32, TypeArrayFloat64, TypeArrayTime:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,418 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// package containing common functions and features for CFactor to work smoothly.
+// PathUtil contains functions to access values by a dotted path.
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// the error wrapped by a PathError when the path could not be found
+// (check errors.Is).
+var ErrPathNotFound = errors.New("path not found")
+
+// error returned when a dotted path (e.g. "client.address.city" or
+// "hobbies[1]") could not be resolved.
+type PathError struct {
+	// the path requested
+	Path string
+	// the reason of the failure
+	Reason string
+	// the path is not found (missing key, index out of range or nil value);
+	// false => the path itself is invalid or the value type does not match
+	NotFound bool
+}
+
+// return the description of the failure.
+func (e *PathError) Error() string {
+	return fmt.Sprintf("path [%v]: %v", e.Path, e.Reason)
+}
+
+// return ErrPathNotFound if the path is not found (errors.Is support).
+func (e *PathError) Unwrap() error {
+	if e.NotFound {
+		return ErrPathNotFound
+	}
+	return nil
+}
+
+// a segment of a dotted path e.g. "hobbies[1]" => name "hobbies", indices [1].
+type pathSegment struct {
+	name string
+	indices []int
+}
+
+// function to return the value identified by the dotted path; the path
+// walks Struct(s) (any depth; fields are matched by their toml key),
+// maps (by entry key) and slices / arrays (by index e.g. "hobbies[1]",
+// "matrix[0][1]"). Pointers and interfaces are followed. An optional naming
+// strategy could be provided for fields without a toml Tag.
+// Returns a *PathError if the path could not be resolved.
+func GetValueByPath(object interface{}, path string, strategy ...KeyNamingStrategy) (reflect.Value, error) {
+	target, err := resolvePath(object, path, getNamingStrategy(strategy))
+	return target.value, err
+}
+
+// function to set the value identified by the dotted path (check
+// GetValueByPath) of the given Struct pointer. String values are coerced
+// into the target's type with the same rules as loading (e.g. "30" for an
+// int, "1990-02-28" for a time.Time, "[a, b]" for a []string); any other
+// value must be assignable (or numerically convertible) to the target.
+// Returns a *PathError if the path could not be resolved or the value could
+// not be coerced.
+func SetValueByPath(object interface{}, path string, value interface{}, strategy ...KeyNamingStrategy) error {
+	if !IsNonNilPointer(object) {
+		return &PathError{ Path: path, Reason: "a non nil pointer to the config object is required" }
+	}
+	target, err := resolvePath(object, path, getNamingStrategy(strategy))
+	if err != nil {
+		return err
+	}
+	targetType := target.value.Type()
+	// an interface{} target (e.g. map[string]interface{} entries) keeps the
+	// type of its current value
+	if targetType.Kind() == reflect.Interface && !target.value.IsNil() {
+		targetType = target.value.Elem().Type()
+	}
+	newVal, err := CoerceValue(value, targetType, path)
+	if err != nil {
+		return err
+	}
+	if target.mapVal.IsValid() {
+		target.mapVal.SetMapIndex(target.mapKey, newVal)
+		return nil
+	}
+	if !target.value.CanSet() {
+		return &PathError{ Path: path, Reason: "the value could not be set (e.g. a Struct held by a map)" }
+	}
+	target.value.Set(newVal)
+	return nil
+}
+
+// the value resolved by a path; map entries are not addressable, hence the
+// map and the entry's key are kept for setting the entry.
+type pathTarget struct {
+	value reflect.Value
+	mapVal reflect.Value
+	mapKey reflect.Value
+}
+
+func resolvePath(object interface{}, path string, strategy KeyNamingStrategy) (target pathTarget, err error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return target, err
+	}
+	val := reflect.ValueOf(object)
+	prefix := ""
+
+	for idx := 0; idx < len(segments); {
+		if val, err = getIndirectValue(val, path, prefix); err != nil {
+			return pathTarget{}, err
+		}
+		consumed := 0
+		target = pathTarget{}
+		switch val.Kind() {
+		case reflect.Struct:
+			val, prefix, consumed = getFieldValueByPathSegments(val, prefix, segments[idx:], strategy)
+		case reflect.Map:
+			target.mapVal = val
+			val, target.mapKey, prefix, consumed = getMapValueByPathSegment(val, prefix, segments[idx])
+		default:
+			return pathTarget{}, &PathError{ Path: path, Reason: fmt.Sprintf("[%v] is not a table", prefix) }
+		}
+		if consumed == 0 {
+			return pathTarget{}, &PathError{ Path: path, NotFound: true,
+				Reason: fmt.Sprintf("key [%v] not found", getPathKey(prefix, segments[idx].name)) }
+		}
+		idx += consumed
+
+		// indices of the last segment consumed
+		for _, index := range segments[idx-1].indices {
+			if val, err = getIndirectValue(val, path, prefix); err != nil {
+				return pathTarget{}, err
+			}
+			if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+				return pathTarget{}, &PathError{ Path: path, Reason: fmt.Sprintf("[%v] is not an array", prefix) }
+			}
+			if index >= val.Len() {
+				return pathTarget{}, &PathError{ Path: path, NotFound: true,
+					Reason: fmt.Sprintf("index [%v] out of range of [%v] (length %v)", index, prefix, val.Len()) }
+			}
+			val = val.Index(index)
+			prefix = fmt.Sprintf("%v[%v]", prefix, index)
+			target = pathTarget{}
+		}	// end -- for (indices)
+	}	// end -- for (segments)
+	target.value = val
+	return target, nil
+}
+
+// function to convert the value into the target type; strings are parsed
+// with the loading rules (e.g. "30" => int, "1990-02-28" => time.Time),
+// numbers are converted among the numeric types (check
+// ConvertNumericValue). "path" is used in the returned *PathError only.
+func CoerceValue(value interface{}, targetType reflect.Type, path string) (newVal reflect.Value, err error) {
+	val := reflect.ValueOf(value)
+	if !val.IsValid() {
+		return reflect.Zero(targetType), nil
+	}
+	if val.Type().AssignableTo(targetType) {
+		return val, nil
+	}
+	if IsNumericKind(val.Kind()) && IsNumericKind(targetType.Kind()) {
+		return ConvertNumericValue(val, targetType, path)
+	}
+	if val.Kind() != reflect.String {
+		return reflect.Value{}, &PathError{ Path: path,
+			Reason: fmt.Sprintf("cannot convert [%v] to [%v]", val.Type(), targetType) }
+	}
+	if targetType.Kind() == reflect.Ptr {
+		elemVal, err := CoerceValue(value, targetType.Elem(), path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		newVal = reflect.New(targetType.Elem())
+		newVal.Elem().Set(elemVal)
+		return newVal, nil
+	}
+	return coerceString(val.String(), targetType, path)
+}
+
+func coerceString(value string, targetType reflect.Type, path string) (newVal reflect.Value, err error) {
+	// setValueByDataType panics on conversion failures
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PathError{ Path: path, Reason: fmt.Sprintf("%v", r) }
+		}
+	}()
+	// strip the " symbol if any (same as loading)
+	value = strings.TrimSpace(strings.Replace(value, "\"", "", -1))
+	newVal = reflect.New(targetType).Elem()
+
+	switch targetType.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iVal, cErr := strconv.ParseInt(value, 10, targetType.Bits())
+		if cErr != nil {
+			return reflect.Value{}, &PathError{ Path: path, Reason: fmt.Sprintf("cannot convert [%v] to %v type", value, targetType) }
+		}
+		newVal.SetInt(iVal)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uVal, cErr := strconv.ParseUint(value, 10, targetType.Bits())
+		if cErr != nil {
+			return reflect.Value{}, &PathError{ Path: path, Reason: fmt.Sprintf("cannot convert [%v] to %v type", value, targetType) }
+		}
+		newVal.SetUint(uVal)
+	default:
+		setValueByDataType(targetType.String(), newVal, path, value, isValueAnArray(value))
+	}
+	return newVal, nil
+}
+
+// function to convert the numeric value into the numeric target type; values
+// overflowing the target type (e.g. 300 => int8, -1 => uint8) and non
+// integral floats for integer types (e.g. 3.9 => int) are rejected with a
+// *PathError instead of being wrapped / truncated. "path" is used in the
+// returned *PathError only.
+func ConvertNumericValue(val reflect.Value, targetType reflect.Type, path string) (reflect.Value, error) {
+	target := reflect.New(targetType).Elem()
+	overflow := false
+
+	switch {
+	case isIntKind(val.Kind()):
+		iVal := val.Int()
+		if isIntKind(targetType.Kind()) {
+			overflow = target.OverflowInt(iVal)
+		} else if isUintKind(targetType.Kind()) {
+			overflow = iVal < 0 || target.OverflowUint(uint64(iVal))
+		}
+	case isUintKind(val.Kind()):
+		uVal := val.Uint()
+		if isIntKind(targetType.Kind()) {
+			overflow = uVal > math.MaxInt64 || target.OverflowInt(int64(uVal))
+		} else if isUintKind(targetType.Kind()) {
+			overflow = target.OverflowUint(uVal)
+		}
+	default:
+		fVal := val.Float()
+		if !isIntKind(targetType.Kind()) && !isUintKind(targetType.Kind()) {
+			overflow = target.OverflowFloat(fVal)
+			break
+		}
+		if math.IsNaN(fVal) || math.IsInf(fVal, 0) || fVal != math.Trunc(fVal) {
+			return reflect.Value{}, &PathError{ Path: path,
+				Reason: fmt.Sprintf("[%v] is not an integral value for [%v]", fVal, targetType) }
+		}
+		if isIntKind(targetType.Kind()) {
+			// 2^63 is the first float64 out of the int64 range
+			overflow = fVal < math.MinInt64 || fVal >= math.MaxInt64 || target.OverflowInt(int64(fVal))
+		} else {
+			overflow = fVal < 0 || fVal >= math.MaxUint64 || target.OverflowUint(uint64(fVal))
+		}
+	}
+	if overflow {
+		return reflect.Value{}, &PathError{ Path: path,
+			Reason: fmt.Sprintf("[%v] overflows [%v]", val.Interface(), targetType) }
+	}
+	return val.Convert(targetType), nil
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// function to check if the kind is an integer or a float.
+func IsNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// split the dotted path into segments e.g. "a.b[0][1]" => a, b (indices 0, 1).
+func parsePath(path string) ([]pathSegment, error) {
+	if len(strings.TrimSpace(path)) == 0 {
+		return nil, &PathError{ Path: path, Reason: "the path is empty" }
+	}
+	segments := []pathSegment{}
+	for _, part := range strings.Split(path, ".") {
+		segment := pathSegment{ name: part }
+		if bracketIdx := strings.Index(part, "["); bracketIdx != -1 {
+			segment.name = part[:bracketIdx]
+			indices := part[bracketIdx:]
+
+			for len(indices) > 0 {
+				closeIdx := strings.Index(indices, "]")
+				if indices[0] != '[' || closeIdx == -1 {
+					return nil, &PathError{ Path: path, Reason: fmt.Sprintf("invalid index in [%v]", part) }
+				}
+				index, err := strconv.Atoi(indices[1:closeIdx])
+				if err != nil || index < 0 {
+					return nil, &PathError{ Path: path, Reason: fmt.Sprintf("invalid index in [%v]", part) }
+				}
+				segment.indices = append(segment.indices, index)
+				indices = indices[closeIdx+1:]
+			}	// end -- for (indices)
+		}
+		if len(strings.TrimSpace(segment.name)) == 0 {
+			return nil, &PathError{ Path: path, Reason: fmt.Sprintf("empty key in [%v]", part) }
+		}
+		segments = append(segments, segment)
+	}	// end -- for (parts)
+	return segments, nil
+}
+
+// follow the pointers and interfaces; a nil value is reported as not found.
+func getIndirectValue(val reflect.Value, path, prefix string) (reflect.Value, error) {
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) {
+		if val.IsNil() {
+			break
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() || ((val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && val.IsNil()) {
+		return reflect.Value{}, &PathError{ Path: path, NotFound: true, Reason: fmt.Sprintf("[%v] is nil", prefix) }
+	}
+	return val, nil
+}
+
+// return the field matching the leading segments; a field's key could span
+// several segments (e.g. toml:"author.firstName" on the root Struct), the
+// shortest match wins. Keys are matched as full keys (prefix included) or
+// relative to the Struct's key. Returns the number of segments consumed
+// (0 => not found).
+func getFieldValueByPathSegments(structVal reflect.Value, prefix string, segments []pathSegment, strategy KeyNamingStrategy) (reflect.Value, string, int) {
+	names := []string{}
+	for idx, segment := range segments {
+		names = append(names, segment.name)
+		localKey := strings.Join(names, ".")
+
+		if fieldVal, ok := getFieldValueByPathKey(structVal, prefix, getPathKey(prefix, localKey), localKey, strategy); ok {
+			return fieldVal, getPathKey(prefix, localKey), idx + 1
+		}
+		// indices are only allowed on the last segment of a key
+		if len(segment.indices) > 0 {
+			break
+		}
+	}	// end -- for (segments)
+	return reflect.Value{}, prefix, 0
+}
+
+func getFieldValueByPathKey(structVal reflect.Value, prefix, key, localKey string, strategy KeyNamingStrategy) (reflect.Value, bool) {
+	structType := structVal.Type()
+
+	for idx := 0; idx < structType.NumField(); idx++ {
+		tag := NewTagStructureByStrategy(structType.Field(idx), prefix, strategy)
+		if tag.Skip {
+			continue
+		}
+		if tag.Inline && structType.Field(idx).Type.Kind() == reflect.Struct {
+			if fieldVal, ok := getFieldValueByPathKey(structVal.Field(idx), prefix, key, localKey, strategy); ok {
+				return fieldVal, true
+			}
+			continue
+		}
+		if tag.IsKeyMatched(key) || (len(prefix) > 0 && tag.IsKeyMatched(localKey)) {
+			return structVal.Field(idx), true
+		}
+	}	// end -- for (fields)
+	return reflect.Value{}, false
+}
+
+// return the map entry (plus the entry's key) of the segment's name;
+// returns the number of segments consumed (0 => not found).
+func getMapValueByPathSegment(mapVal reflect.Value, prefix string, segment pathSegment) (reflect.Value, reflect.Value, string, int) {
+	keyType := mapVal.Type().Key()
+	if keyType.Kind() != reflect.String {
+		return reflect.Value{}, reflect.Value{}, prefix, 0
+	}
+	mapKey := reflect.ValueOf(segment.name).Convert(keyType)
+	entryVal := mapVal.MapIndex(mapKey)
+	if !entryVal.IsValid() {
+		return reflect.Value{}, reflect.Value{}, prefix, 0
+	}
+	return entryVal, mapKey, getPathKey(prefix, segment.name), 1
+}
+
+// join the prefix and the key with a dot.
+func getPathKey(prefix, key string) string {
+	if len(prefix) == 0 {
+		return key
+	}
+	return prefix + "." + key
+}
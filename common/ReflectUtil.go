@@ -19,6 +19,7 @@ package common
 
 import (
 	"reflect"
+	"sort"
 	"strings"
 	"errors"
 	"strconv"
@@ -83,6 +84,29 @@ const TypeMapStringInterface = "map[string]interface {}"
 // string presentation for a "pointer"
 const TypePointerSymbol = "*"
 
+// the Tag providing the alternative (old) keys of a field; comma separated
+// e.g. alias:"workingHoursDay"
+const TagAlias = "alias"
+// the Tag marking a field's aliases (or the field itself if no aliases are
+// given) as deprecated; the value is the migration hint
+// e.g. deprecated:"use work.hoursPerDay"
+const TagDeprecated = "deprecated"
+// the Tag providing the comment written above the field's key on save
+// e.g. comment:"Max concurrent workers (1-64)"; "\n" starts a new line
+const TagComment = "comment"
+// the Tag providing the comment written above a child Struct's (or map's)
+// [table] header on save e.g. doc:"Worker pool settings"
+const TagDoc = "doc"
+
+// the toml Tag's value to skip a field (e.g. toml:"-")
+const TagValueSkip = "-"
+// the toml Tag's option to omit the field on save if its value is empty
+// (e.g. toml:"name,omitempty")
+const TagOptionOmitEmpty = "omitempty"
+// the toml Tag's option to treat the child Struct's fields as the fields
+// of the parent Struct (e.g. toml:",inline")
+const TagOptionInline = "inline"
+
 // wraps a struct field's "Tag"
 type TagStructure struct {
     // config type (toml or json)
@@ -91,6 +115,77 @@ type TagStructure struct {
 	Field string
 	// additional information of the struct's field (e.g. is it a "parent")
 	Additional string
+
+	// the field is skipped on load and save (toml:"-")
+	Skip bool
+	// the field is omitted on save if its value is empty (toml:"name,omitempty")
+	OmitEmpty bool
+	// the child Struct's fields are treated as the parent's fields (toml:",inline")
+	Inline bool
+	// the key is matched case-insensitively (check NamingCaseInsensitive)
+	CaseInsensitive bool
+
+	// alternative (old) keys populating the same field (alias:"oldKey")
+	Aliases []string
+	// migration hint of the deprecated keys (deprecated:"use newKey")
+	Deprecated string
+
+	// comment written above the key on save (comment:"...")
+	Comment string
+	// comment written above the [table] header on save (doc:"...")
+	Doc string
+}
+
+// create a TagStructure based on the given Struct field's Tag. The toml Tag's
+// format mirrors encoding/json => toml:"name,option1,option2"; toml:"-"
+// skips the field (use toml:"-," for a key named "-").
+func NewTagStructure(field reflect.StructField) TagStructure {
+	tagValue := field.Tag.Get(TagTOML)
+	tag := TagStructure{
+		CType: ConfigTypeTOML,
+		Additional: field.Tag.Get(TagAdditional),
+		Deprecated: strings.TrimSpace(field.Tag.Get(TagDeprecated)),
+		Comment: field.Tag.Get(TagComment),
+		Doc: field.Tag.Get(TagDoc),
+	}
+	if aliases := field.Tag.Get(TagAlias); len(aliases) > 0 {
+		for _, alias := range strings.Split(aliases, ",") {
+			if alias = strings.TrimSpace(alias); len(alias) > 0 {
+				tag.Aliases = append(tag.Aliases, alias)
+			}
+		}
+	}
+	if strings.Compare(tagValue, TagValueSkip) == 0 {
+		tag.Skip = true
+		return tag
+	}
+	parts := strings.Split(tagValue, ",")
+	tag.Field = strings.TrimSpace(parts[0])
+
+	for _, option := range parts[1:] {
+		switch strings.TrimSpace(option) {
+		case TagOptionOmitEmpty:
+			tag.OmitEmpty = true
+		case TagOptionInline:
+			tag.Inline = true
+		}
+	}	// end -- for (options)
+	return tag
+}
+
+// check if the given key matches any of the field's aliases.
+func (t TagStructure) IsAliasMatched(key string) bool {
+	for _, alias := range t.Aliases {
+		if (t.CaseInsensitive && strings.EqualFold(alias, key)) || strings.Compare(alias, key) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// check if the field points to another Struct (hierarchical => additional:"parent").
+func (t TagStructure) IsParent() bool {
+	return strings.Compare(t.Additional, ConfigTypeParent) == 0
 }
 
 // create a new instance based on the given "type".
@@ -116,6 +211,29 @@ func getStructRefByType(structRefMap map[string]interface{}, structType reflect.
 	return structRef
 }
 
+// seed the map with copies of the existing child Structs (parent fields,
+// top-down); values set before the loading (e.g. defaults set by the
+// BeforeLoad hooks) are hence kept when the child keys are populated.
+func seedStructRefMap(structRefMap map[string]interface{}, objVal reflect.Value) {
+	if objVal.Kind() != reflect.Struct {
+		return
+	}
+	objType := objVal.Type()
+
+	for i := 0; i < objType.NumField(); i++ {
+		fieldVal := objVal.Field(i)
+		if !NewTagStructure(objType.Field(i)).IsParent() || fieldVal.Kind() != reflect.Struct || !fieldVal.CanInterface() {
+			continue
+		}
+		if _, ok := structRefMap[fieldVal.Type().String()]; !ok {
+			structRef := NewStructPointerByType(fieldVal.Type())
+			structRef.Elem().Set(fieldVal)
+			structRefMap[fieldVal.Type().String()] = structRef.Interface()
+		}
+		seedStructRefMap(structRefMap, fieldVal)
+	}	// end -- for (fields)
+}
+
 /**
  *	helper method to check if the given string is related to an "array" syntax
  */
@@ -129,11 +247,30 @@ func isValueAnArray(value string) bool {
 // configuration lines read.
 // PS. the lifeCycle hook function "SetStructsReferences" would be invoked here.
 func PopulateFieldValues(lines []string, configType string, object interface{}, objectType reflect.Type) (bool, error) {
+	return PopulateFieldValuesWithMetaData(lines, configType, object, objectType, nil)
+}
+
+// function to populate the targeted Struct reference field(s) based on the
+// configuration lines read. The keys defined, undecoded and defaulted
+// would be recorded into the given "metaData" (if non nil).
+// PS. the lifeCycle hook function "SetStructsReferences" would be invoked here.
+func PopulateFieldValuesWithMetaData(lines []string, configType string, object interface{}, objectType reflect.Type, metaData *DecodeMetaData) (bool, error) {
+	return PopulateFieldValuesByStrategy(lines, configType, object, objectType, metaData, NamingNone)
+}
+
+// function to populate the targeted Struct reference field(s) based on the
+// configuration lines read (same as PopulateFieldValuesWithMetaData).
+// Fields without a toml Tag are matched by the key derived through the
+// given naming "strategy" (check KeyNamingStrategy).
+func PopulateFieldValuesByStrategy(lines []string, configType string, object interface{}, objectType reflect.Type, metaData *DecodeMetaData, strategy KeyNamingStrategy) (bool, error) {
 	if IsValidPointer(object) == true {
 		// a map for storing the inner objects / structs
 		structRefMap := make(map[string]interface{})
+		seedStructRefMap(structRefMap, reflect.Indirect(reflect.ValueOf(object)))
+		// the current [table] header; keys under it are relative to the table
+		table := ""
 
-		for _, ln := range lines {
+		for lineIdx, ln := range lines {
 			// trim the lines (spaces removal)
 			if len(ln)>0 {
 				ln = strings.TrimSpace(ln)
@@ -150,19 +287,30 @@ func PopulateFieldValues(lines []string, configType string, object interface{},
 					// * return true, nil
 					continue
 				}
-				kv := strings.Split(ln, "=")
+				if tableKey, ok := getTableHeaderKey(ln); ok {
+					table = tableKey
+					continue
+				}
+				kv := strings.SplitN(ln, "=", 2)
 				if len(kv) == 2 {
 					k := strings.TrimSpace(kv[0])
-					v := strings.TrimSpace(kv[1])
+					// strip the inline comment (if any)
+					v := strings.TrimSpace(kv[1][:GetInlineCommentIndex(kv[1], 0)])
+					if len(table) > 0 {
+						k = table + "." + k
+					}
 
 					// check if "v" is an array
-					if isValueAnArray(v) {
-						// handle array population plus array type policy
-						populateStringValByFieldName(object, objectType, k, v, true, &structRefMap)
+					// (handle array population plus array type policy)
+					matched, tag := populateStringValByFieldName(object, objectType, k, v, isValueAnArray(v), &structRefMap, "", strategy)
 //fmt.Println(k, "=", v, "=>",structRefMap)
-					} else {
-						populateStringValByFieldName(object, objectType, k, v, false, &structRefMap)
-					}	// end -- if (array???)
+					if metaData != nil {
+						if matched {
+							metaData.setDefinedKey(k, lineIdx + 1, tag)
+						} else {
+							metaData.Undecoded[k] = lineIdx + 1
+						}
+					}	// end -- if (metaData available)
 				}
 			}	// end -- if (lines is non empty)
 			// * return true, nil
@@ -174,40 +322,60 @@ func PopulateFieldValues(lines []string, configType string, object interface{},
 		if err := setStructRefsToInterfaceByLifeCycleHooks(&structRefMap, object); err != nil {
 			return false, err
 		}
+		if metaData != nil {
+			metaData.setDefaultedKeys(objectType, strategy)
+		}
 	}
 	// * return false, errors.New("object / value provided is non-valid")
 	return true, nil
 }
 
-func getLifeCycleHookMethodByName(methodName string, object interface{}) reflect.Value {
-	objVal := reflect.ValueOf(object)
-
-	return objVal.MethodByName(methodName)
+// return the key of a [table] header line (e.g. "[client.address]" => "client.address").
+func getTableHeaderKey(line string) (string, bool) {
+	if idx := strings.Index(line, "#"); idx > 0 {
+		line = strings.TrimSpace(line[:idx])
+	}
+	if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") && !strings.Contains(line, "=") {
+		return strings.TrimSpace(line[1:len(line)-1]), true
+	}
+	return "", false
 }
 
-// TODO: to remove the sticky dependency of MethodSetStructsReference !!!
+// set back the child Struct references (hierarchical Structs) to the object.
+// The IConfigLifeCycleHooks hook (SetStructsReferences) is used if
+// implemented by the object; else the references are set through reflection
+// on the fields marked as additional:"parent".
 func setStructRefsToInterfaceByLifeCycleHooks(structRefMap *map[string]interface{}, object interface{}) (error) {
-	// use the ugly approach to setStructs through relection + method invocation
-	methodVal := getLifeCycleHookMethodByName(interfaces.MethodSetStructsReference, object)
-	if !methodVal.IsValid() {
-		// TODO: sometimes the method is Invalid... why??
-		// fmt.Println("** somehow the method is invalid")
-		return fmt.Errorf("unknown method [%v]", interfaces.MethodSetStructsReference)
-		
-	} else {
+	if hooks, ok := object.(interfaces.IConfigLifeCycleHooks); ok {
+		return hooks.SetStructsReferences(structRefMap)
+	}
+	return setStructRefsToFields(*structRefMap, reflect.Indirect(reflect.ValueOf(object)))
+}
 
-		methodValType := methodVal.Type()
-		// create num of arguments
-		args := make([]reflect.Value, methodValType.NumIn())
-		args[0] = reflect.ValueOf(structRefMap)
-		outArgs := methodVal.Call(args)
+// set back the child Struct references to the matching "parent" fields;
+// parent fields are set first and then their own child fields (top-down),
+// hence multiple levels of hierarchy are supported.
+func setStructRefsToFields(structRefMap map[string]interface{}, objVal reflect.Value) error {
+	if len(structRefMap) == 0 || objVal.Kind() != reflect.Struct {
+		return nil
+	}
+	objType := objVal.Type()
 
-		if len(outArgs) > 0 {
-			if !outArgs[0].IsNil() {
-				return outArgs[0].Interface().(error)
+	for i := 0; i < objType.NumField(); i++ {
+		if strings.Compare(objType.Field(i).Tag.Get(TagAdditional), ConfigTypeParent) != 0 {
+			continue
+		}
+		fieldVal := objVal.Field(i)
+		if structRef, ok := structRefMap[fieldVal.Type().String()]; ok {
+			if !fieldVal.CanSet() {
+				return fmt.Errorf("could not set the child Struct to field [%v]", objType.Field(i).Name)
 			}
+			fieldVal.Set(reflect.Indirect(reflect.ValueOf(structRef)))
 		}
-	}
+		if err := setStructRefsToFields(structRefMap, fieldVal); err != nil {
+			return err
+		}
+	}	// end -- for (fields)
 	return nil
 }
 
@@ -322,7 +490,8 @@ func isStructNameMatched(actualRefName, targetRefName string) (string, bool) {
 
 func populateStringValByFieldName(
 	object interface{}, objectType reflect.Type, key string, value string,
-	isArray bool, structRefMap *map[string]interface{}) {
+	isArray bool, structRefMap *map[string]interface{},
+	prefix string, strategy KeyNamingStrategy) (bool, TagStructure) {
 
 	fLen := objectType.NumField()
 	//objVal := reflect.ValueOf(object).Elem()
@@ -333,51 +502,38 @@ func populateStringValByFieldName(
 
 	for i:=0; i<fLen; i++ {
 		typeField := objectType.Field(i)
-		tags := typeField.Tag
+		tag := NewTagStructureByStrategy(typeField, prefix, strategy)
+
+		if tag.Skip {
+			continue
 
-		if strings.Compare(tags.Get(TagAdditional), ConfigTypeParent) == 0 {
-//fmt.Println("bb", key,"=", value," tagToml =>", tags.Get(TagTOML), " FIELDNAME => ", typeField.Name, " type =>", objectType)
+		} else if tag.IsParent() {
+//fmt.Println("bb", key,"=", value," tagToml =>", tag.Field, " FIELDNAME => ", typeField.Name, " type =>", objectType)
 			/*
 			 *	as the reflected value is not a real object instance...
-			 *	NEED to use alternatives (recursively populate...)
+			 *	NEED to use alternatives (recursively populate...); the
+			 *	child Struct references are set back by the lifeCycle hook later
 			 */
 			// check if any related struct reference already there...
 			innerObjInterface := getStructRefByType(*structRefMap, objVal.Field(i).Type())
-			innerObjVal := reflect.ValueOf(innerObjInterface)
-			innerObjValIndirected := reflect.Indirect(innerObjVal)
-			innerObjType := innerObjValIndirected.Type()
-			numFieldsInner := innerObjType.NumField()
-
-			for i2:=0; i2<numFieldsInner; i2++ {
-				innerObjField := innerObjType.Field(i2)
-				innerObjTags := innerObjField.Tag.Get(TagTOML)
-				// TODO: check if it is parent as well??? recursive calling method to populate values (print key, value for confirm)
-				if strings.Compare(ConfigTypeParent, innerObjField.Tag.Get(TagAdditional)) == 0 {
-					//innerStructObj := innerObjValIndirected.Field(i2).Interface()
-					innerStructObj := innerObjValIndirected.Interface()
-//fmt.Println("ff", reflect.TypeOf(innerStructObj))
-					populateStringValByFieldName(innerStructObj, reflect.TypeOf(innerStructObj), key, value, isValueAnArray(value), structRefMap)
-//fmt.Println("cc", innerStructObj, "typeof-", reflect.TypeOf(innerStructObj), "map =", structRefMap)
-				} else {
-					//fmt.Println("bb inner", innerObjField.Tag.Get(TagAdditional), innerObjField.Name)
-					// check if the tags match the toml name
-					if strings.Compare(innerObjTags, key) == 0 {
-						setValueByDataType(
-							innerObjValIndirected.Field(i2).Type().String(),
-							innerObjValIndirected.Field(i2), key, value, isArray)
-						break
-					}	// end -- if (tags matched)
-				}	// end -- if (parent found ??)
-			}	// end -- for (innerObject iteration)
-		} else {
+			innerObjType := reflect.Indirect(reflect.ValueOf(innerObjInterface)).Type()
+
+			if matched, matchedTag := populateStringValByFieldName(innerObjInterface, innerObjType, key, value, isArray, structRefMap, tag.Field, strategy); matched {
+				return true, matchedTag
+			}
+		} else if tag.Inline && typeField.Type.Kind() == reflect.Struct && objVal.Field(i).CanAddr() {
+			// inline => the child Struct's fields are treated as this Struct's fields
+			if matched, matchedTag := populateStringValByFieldName(objVal.Field(i).Addr().Interface(), typeField.Type, key, value, isArray, structRefMap, prefix, strategy); matched {
+				return true, matchedTag
+			}
+		} else if tag.IsKeyMatched(key) || tag.IsAliasMatched(key) {
 //fmt.Println("ff simple fields - ", key, "vs", value)
-			if strings.Compare(tags.Get(TagTOML), key) == 0 {
-				// ### reflect.ValueOf(&r).Elem().Field(i).SetInt( i64 )
-				setValueByDataType(typeField.Type.String(), objVal.Field(i), key, value, isArray)
-				break
-			}	// end -- if (key matched)
-		}	// end -- if (additional_info == parent)
+			// ### reflect.ValueOf(&r).Elem().Field(i).SetInt( i64 )
+			setValueByDataType(typeField.Type.String(), objVal.Field(i), key, value, isArray)
+			return true, tag
+		}	// end -- if (skip / parent / inline / key matched)
 	}	// end -- for (fLen)
+	return false, TagStructure{}
 }
 
 /*
@@ -430,7 +586,7 @@ func setValueByDataType(dataType string, targetField reflect.Value, k, v string,
 		targetField.SetString(v)
 
 	} else if strings.Compare(dataType, TypeFloat32) == 0 || strings.Compare(dataType, TypeFloat64) == 0 {
-		fVal, cErr := strconv.ParseFloat(v, 64)
+		fVal, cErr := ParseStringToFloat(v, targetField.Type().Bits())
 		if cErr != nil {
 			panic(errors.New(fmt.Sprintf("cannot convert [%v] to float32 / 64 type for field [%v]", v, k)))
 		}
@@ -508,187 +664,103 @@ func setValueByDataType(dataType string, targetField reflect.Value, k, v string,
 /*	get value for TOML based on dataType	*/
 /* ---------------------------------------- */
 
-// return the string value of the Struct reference's field (identified by "key")
-func GetStringValueByTomlField(object interface{}, objectType reflect.Type, key string) (bool, string) {
-	fLen := objectType.NumField()
-	objVal := reflect.ValueOf(object)
-
-	for i:=0; i<fLen; i++ {
-		tags := objectType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagAdditional), ConfigTypeParent)==0 {
-			// met a "parent" level field
-			ok, sVal := getStringValueByTomlFieldUnderChildStruct(reflect.ValueOf(object).Field(i), key)
-			if ok {
-				return ok, sVal
-			}
-		} else if strings.Compare(tags.Get(TagTOML), key) == 0 {
-			return true, objVal.Field(i).String() //return true, fmt.Sprint(objVal.Field(i).Interface())
-		}	// end -- if (key matched)
-	}	// end -- for (fLen)
-	return false, ""
+// return the optional naming strategy (NamingNone if not provided).
+func getNamingStrategy(strategy []KeyNamingStrategy) KeyNamingStrategy {
+	if len(strategy) > 0 {
+		return strategy[0]
+	}
+	return NamingNone
 }
-func getStringValueByTomlFieldUnderChildStruct(field reflect.Value, k string) (bool, string) {
-	fieldType := field.Type()
-	fLen := fieldType.NumField()
 
-	for i:=0; i<fLen; i++ {
-		// check if tag contains "k"
-		tags := fieldType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagTOML), k)==0 {
-			innerField := field.Field(i)
-			return true, innerField.String()
-		}
-	}	// end -- for(numField len)
-	return false, "not found"
+// return the string value of the Struct reference's field (identified by "key");
+// an optional naming strategy could be provided for fields without a toml Tag
+// (the same applies to the other getters).
+func GetStringValueByTomlField(object interface{}, objectType reflect.Type, key string, strategy ...KeyNamingStrategy) (bool, string) {
+	fieldVal, ok := GetFieldValueByTomlKeyAndStrategy(reflect.ValueOf(object), key, getNamingStrategy(strategy))
+	if ok && fieldVal.Kind() == reflect.String {
+		return true, fieldVal.String() //return true, fmt.Sprint(objVal.Field(i).Interface())
+	}
+	return false, ""
 }
 
 // return the int value of the Struct reference's field (identified by "key")
-func GetIntValueByTomlField(object interface{}, objectType reflect.Type, key string) (bool, int64) {
-	fLen := objectType.NumField()
-	objVal := reflect.ValueOf(object)
-
-	for i:=0; i<fLen; i++ {
-		tags := objectType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagAdditional), ConfigTypeParent)==0 {
-			// met a "parent" level field
-			//fmt.Println(tags, "v", key, "b", reflect.ValueOf(object).Field(i))
-			ok, iVal := getIntValueByTomlFieldUnderChildStruct(reflect.ValueOf(object).Field(i), key)
-			if ok {
-				return ok, iVal
-			}	// end -- if (ok)
-		} else if strings.Compare(tags.Get(TagTOML), key) == 0 {
-			return true, reflect.ValueOf(objVal.Field(i).Int()).Interface().(int64)
-		}	// end -- if (key matched)
-	}	// end -- for (fLen)
-	return false, -1
-}
-func getIntValueByTomlFieldUnderChildStruct(field reflect.Value, k string) (bool, int64) {
-	fieldType := field.Type()
-	fLen := fieldType.NumField()
-
-	for i:=0; i<fLen; i++ {
-		// check if tag contains "k"
-		tags := fieldType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagTOML), k)==0 {
-			innerField := field.Field(i)
-			return true, innerField.Int()
+func GetIntValueByTomlField(object interface{}, objectType reflect.Type, key string, strategy ...KeyNamingStrategy) (bool, int64) {
+	fieldVal, ok := GetFieldValueByTomlKeyAndStrategy(reflect.ValueOf(object), key, getNamingStrategy(strategy))
+	if ok {
+		switch fieldVal.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return true, fieldVal.Int()
 		}
-	}	// end -- for(numField len)
+	}
 	return false, -1
 }
 
 // return the float value of the Struct reference's field (identified by "key")
-func GetFloatValueByTomlField(object interface{}, objectType reflect.Type, key string) (bool, float64) {
-	fLen := objectType.NumField()
-	objVal := reflect.ValueOf(object)
-
-	for i:=0; i<fLen; i++ {
-		tags := objectType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagAdditional), ConfigTypeParent)==0 {
-			// met a "parent" level field
-			ok, iVal := getFloatValueByTomlFieldUnderChildStruct(reflect.ValueOf(object).Field(i), key)
-			if ok {
-				return ok, iVal
-			}	// end -- if (ok)
-		} else if strings.Compare(tags.Get(TagTOML), key) == 0 {
-			return true, reflect.ValueOf(objVal.Field(i).Float()).Interface().(float64)
-		}	// end -- if (key matched)
-	}	// end -- for (fLen)
-	return false, -1
-}
-func getFloatValueByTomlFieldUnderChildStruct(field reflect.Value, k string) (bool, float64) {
-	fieldType := field.Type()
-	fLen := fieldType.NumField()
-
-	for i:=0; i<fLen; i++ {
-		// check if tag contains "k"
-		tags := fieldType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagTOML), k)==0 {
-			innerField := field.Field(i)
-			return true, innerField.Float()
-		}
-	}	// end -- for(numField len)
+func GetFloatValueByTomlField(object interface{}, objectType reflect.Type, key string, strategy ...KeyNamingStrategy) (bool, float64) {
+	fieldVal, ok := GetFieldValueByTomlKeyAndStrategy(reflect.ValueOf(object), key, getNamingStrategy(strategy))
+	if ok && (fieldVal.Kind() == reflect.Float32 || fieldVal.Kind() == reflect.Float64) {
+		return true, fieldVal.Float()
+	}
 	return false, -1
 }
 
 // return the bool value of the Struct reference's field (identified by "key")
-func GetBoolValueByTomlField(object interface{}, objectType reflect.Type, key string) (bool, bool) {
-	fLen := objectType.NumField()
-	objVal := reflect.ValueOf(object)
-
-	for i:=0; i<fLen; i++ {
-		tags := objectType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagAdditional), ConfigTypeParent)==0 {
-			// met a "parent" level field
-			ok, bVal := getBoolValueByTomlFieldUnderChildStruct(reflect.ValueOf(object).Field(i), key)
-			if ok {
-				return ok, bVal
-			}	// end -- if (ok)
-		} else if strings.Compare(tags.Get(TagTOML), key) == 0 {
-			return true, reflect.ValueOf(objVal.Field(i).Bool()).Interface().(bool)
-		}	// end -- if (key matched)
-	}	// end -- for (fLen)
+func GetBoolValueByTomlField(object interface{}, objectType reflect.Type, key string, strategy ...KeyNamingStrategy) (bool, bool) {
+	fieldVal, ok := GetFieldValueByTomlKeyAndStrategy(reflect.ValueOf(object), key, getNamingStrategy(strategy))
+	if ok && fieldVal.Kind() == reflect.Bool {
+		return true, fieldVal.Bool()
+	}
 	return false, false
 }
-func getBoolValueByTomlFieldUnderChildStruct(field reflect.Value, k string) (bool, bool) {
-	fieldType := field.Type()
-	fLen := fieldType.NumField()
 
-	for i:=0; i<fLen; i++ {
-		// check if tag contains "k"
-		tags := fieldType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagTOML), k)==0 {
-			innerField := field.Field(i)
-			return true, innerField.Bool()
-		}
-	}	// end -- for(numField len)
-	return false, false
+// return the time.Time value of the Struct reference's field (identified by "key")
+func GetTimeValueByTomlField(object interface{}, objectType reflect.Type, key string, strategy ...KeyNamingStrategy) (bool, time.Time) {
+	fieldVal, ok := GetFieldValueByTomlKeyAndStrategy(reflect.ValueOf(object), key, getNamingStrategy(strategy))
+	if ok && strings.Compare(fieldVal.Type().String(), TypeTime) == 0 {
+		return true, fieldVal.Interface().(time.Time)
+	}
+	return false, time.Now()
 }
 
-// return the time.Time value of the Struct reference's field (identified by "key")
-func GetTimeValueByTomlField(object interface{}, objectType reflect.Type, key string) (bool, time.Time) {
-	fLen := objectType.NumField()
-	objVal := reflect.ValueOf(object)
+// return the field's value identified by the toml key; child Struct(s)
+// (additional:"parent") and inline Struct(s) are searched recursively,
+// skipped fields (toml:"-") are ignored.
+func GetFieldValueByTomlKey(objVal reflect.Value, key string) (reflect.Value, bool) {
+	return GetFieldValueByTomlKeyAndStrategy(objVal, key, NamingNone)
+}
 
-	for i:=0; i<fLen; i++ {
-		tags := objectType.Field(i).Tag
-
-		if strings.Compare(tags.Get(TagAdditional), ConfigTypeParent)==0 {
-			// met a "parent" level field
-			ok, tVal := getTimeValueByTomlFieldUnderChildStruct(reflect.ValueOf(object).Field(i), key)
-			if ok {
-				return ok, tVal
-			}	// end -- if (ok)
-		} else if strings.Compare(tags.Get(TagTOML), key) == 0 {
-			tVal := objVal.Field(i).Interface().(time.Time)
-			return true, tVal
-		}	// end -- if (key matched)
-	}	// end -- for (fLen)
-	return false, time.Now()
+// return the field's value identified by the toml key (same as
+// GetFieldValueByTomlKey); fields without a toml Tag are matched by the key
+// derived through the given naming "strategy".
+func GetFieldValueByTomlKeyAndStrategy(objVal reflect.Value, key string, strategy KeyNamingStrategy) (reflect.Value, bool) {
+	return getFieldValueByTomlKey(objVal, key, "", strategy)
 }
-func getTimeValueByTomlFieldUnderChildStruct(field reflect.Value, k string) (bool, time.Time) {
-	fieldType := field.Type()
-	fLen := fieldType.NumField()
 
-	for i:=0; i<fLen; i++ {
-		// check if tag contains "k"
-		tags := fieldType.Field(i).Tag
+func getFieldValueByTomlKey(objVal reflect.Value, key, prefix string, strategy KeyNamingStrategy) (reflect.Value, bool) {
+	objVal = reflect.Indirect(objVal)
+	if objVal.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	objType := objVal.Type()
 
-		if strings.Compare(tags.Get(TagTOML), k)==0 {
-			innerField := field.Field(i)
-			// innerField.Elem().Interface().(time.Time)
-			return true, innerField.Interface().(time.Time)
+	for i := 0; i < objType.NumField(); i++ {
+		tag := NewTagStructureByStrategy(objType.Field(i), prefix, strategy)
+
+		if tag.Skip {
+			continue
+		} else if tag.IsParent() {
+			if fieldVal, ok := getFieldValueByTomlKey(objVal.Field(i), key, tag.Field, strategy); ok {
+				return fieldVal, true
+			}
+		} else if tag.Inline && objType.Field(i).Type.Kind() == reflect.Struct {
+			if fieldVal, ok := getFieldValueByTomlKey(objVal.Field(i), key, prefix, strategy); ok {
+				return fieldVal, true
+			}
+		} else if tag.IsKeyMatched(key) {
+			return objVal.Field(i), true
 		}
-	}	// end -- for(numField len)
-	return false, time.Now()
+	}	// end -- for (fields)
+	return reflect.Value{}, false
 }
 
 /**
@@ -707,80 +779,244 @@ func GetValueByTomlFieldNType(object interface{}, objectType reflect.Type, field
 		if strings.Compare(fieldName, fieldMetaRef.Name)==0 {
 			// found~ based on fieldRef type ... do the casting
 			indirectVal := reflect.Indirect(fieldRef)
-			indirectType := indirectVal.Type()
-			indirectValTypeInString := indirectVal.Type().String()
+			if value, ok := GetTomlScalarValue(indirectVal); ok {
+				return value
+			}
+			// non primitive type met, probably "struct"
+			return getValueByTomlFieldNStructType(indirectVal.Interface(), indirectVal.Type())
+		}
+	}	// end -- for (loop of all fields)
 
-			if strings.Compare(indirectValTypeInString, TypeString) == 0 {
-				// real strings MUST be surrounded by "
-				return "\""+indirectVal.String()+"\""
+	return nil
+}
 
-			} else if strings.Compare(indirectValTypeInString, TypeTime) == 0 {
-				// real time.Time MUST be surrounded by "
-				return "\""+FormatTimeToString("", indirectVal.Interface().(time.Time))+"\""
+// return the value to be written to the toml file for the supported
+// scalar types (strings and time.Time are surrounded by "); false is
+// returned for non supported types (e.g. Struct and maps).
+func GetTomlScalarValue(indirectVal reflect.Value) (interface{}, bool) {
+	indirectValTypeInString := indirectVal.Type().String()
 
-			} else if strings.Compare(indirectValTypeInString, TypeInt) == 0 {
-				return indirectVal.Interface().(int)
+	if strings.Compare(indirectValTypeInString, TypeString) == 0 {
+		// real strings MUST be surrounded by "
+		return "\""+indirectVal.String()+"\"", true
 
-			} else if strings.Compare(indirectValTypeInString, TypeBool) == 0 {
-				return indirectVal.Interface().(bool)
+	} else if strings.Compare(indirectValTypeInString, TypeTime) == 0 {
+		// real time.Time MUST be surrounded by "
+		return "\""+FormatTimeToString("", indirectVal.Interface().(time.Time))+"\"", true
 
-			} else if strings.Compare(indirectValTypeInString, TypeFloat32) == 0 {
-				return indirectVal.Interface().(float32)
+	} else if strings.Compare(indirectValTypeInString, TypeInt) == 0 {
+		return indirectVal.Interface().(int), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeFloat64) == 0 {
-				return indirectVal.Interface().(float64)
+	} else if strings.Compare(indirectValTypeInString, TypeBool) == 0 {
+		return indirectVal.Interface().(bool), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeArrayString) == 0 {
-				return indirectVal.Interface().([]string)
+	} else if strings.Compare(indirectValTypeInString, TypeFloat32) == 0 {
+		return indirectVal.Interface().(float32), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeArrayTime) == 0 {
-				return indirectVal.Interface().([]time.Time)
+	} else if strings.Compare(indirectValTypeInString, TypeFloat64) == 0 {
+		return indirectVal.Interface().(float64), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeArrayInt) == 0 {
-				return indirectVal.Interface().([]int)
+	} else if strings.Compare(indirectValTypeInString, TypeArrayString) == 0 {
+		return indirectVal.Interface().([]string), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeArrayBool) == 0 {
-				return indirectVal.Interface().([]bool)
+	} else if strings.Compare(indirectValTypeInString, TypeArrayTime) == 0 {
+		return indirectVal.Interface().([]time.Time), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeArrayFloat32) == 0 {
-				return indirectVal.Interface().([]float32)
+	} else if strings.Compare(indirectValTypeInString, TypeArrayInt) == 0 {
+		return indirectVal.Interface().([]int), true
 
-			} else if strings.Compare(indirectValTypeInString, TypeArrayFloat64) == 0 {
-				return indirectVal.Interface().([]float64)
-			}
+	} else if strings.Compare(indirectValTypeInString, TypeArrayBool) == 0 {
+		return indirectVal.Interface().([]bool), true
 
-			// non primitive type met, probably "struct"
-			return getValueByTomlFieldNStructType(indirectVal.Interface(), indirectType)
+	} else if strings.Compare(indirectValTypeInString, TypeArrayFloat32) == 0 {
+		return indirectVal.Interface().([]float32), true
 
-			break
-		}
-	}	// end -- for (loop of all fields)
-
-	return nil
+	} else if strings.Compare(indirectValTypeInString, TypeArrayFloat64) == 0 {
+		return indirectVal.Interface().([]float64), true
+	}
+	return nil, false
 }
 
 func getValueByTomlFieldNStructType(object interface{}, objectType reflect.Type) (map[string]interface{}) {
-	// map with all the non-null values
+	return GetTomlValueMap(object)
+}
+
+// return a map of toml key vs value based on the Struct value's fields;
+// skipped fields (toml:"-") and empty fields with the "omitempty" option
+// are excluded, inline Struct(s) are merged into the same level and child
+// Struct(s) are returned as a nested map.
+func GetTomlValueMap(object interface{}) (map[string]interface{}) {
+	return GetTomlValueMapByStrategy(object, NamingNone)
+}
+
+// return a map of toml key vs value based on the Struct value's fields (same
+// as GetTomlValueMap); fields without a toml Tag are keyed by the key derived
+// through the given naming "strategy".
+func GetTomlValueMapByStrategy(object interface{}, strategy KeyNamingStrategy) (map[string]interface{}) {
+	return getTomlValueMapByKeyValues(GetTomlKeyValuesByStrategy(object, strategy, false))
+}
+
+func getTomlValueMapByKeyValues(keyValues []TomlKeyValue) (map[string]interface{}) {
 	valueMap := make(map[string]interface{})
-	numFields := objectType.NumField()
+	for _, kv := range keyValues {
+		if kv.IsTable() {
+			valueMap[kv.Key] = getTomlValueMapByKeyValues(kv.Value.([]TomlKeyValue))
+		} else {
+			valueMap[kv.Key] = kv.Value
+		}
+	}
+	return valueMap
+}
 
-	for idx:=0; idx<numFields; idx++ {
+// struct wrapping a toml key and its value; the value of a child Struct
+// (additional:"parent") is a []TomlKeyValue (a "table").
+type TomlKeyValue struct {
+	Key string
+	Value interface{}
+	// the comment from the comment:"..." Tag (doc:"..." Tag for tables)
+	Comment string
+}
+
+// check if the value is a table (the key values of a child Struct).
+func (kv TomlKeyValue) IsTable() bool {
+	_, ok := kv.Value.([]TomlKeyValue)
+	return ok
+}
+
+// return the toml key values based on the Struct value's fields (same rules
+// as GetTomlValueMapByStrategy); the key values are in Struct field order
+// (or sorted by key if "sorted" is true) with the scalars placed before
+// the tables as TOML requires.
+func GetTomlKeyValuesByStrategy(object interface{}, strategy KeyNamingStrategy, sorted bool) []TomlKeyValue {
+	return getTomlKeyValues(reflect.Indirect(reflect.ValueOf(object)), "", strategy, sorted)
+}
+
+func getTomlKeyValues(objectVal reflect.Value, prefix string, strategy KeyNamingStrategy, sorted bool) []TomlKeyValue {
+	return orderTomlKeyValues(getTomlFieldKeyValues(objectVal, prefix, strategy, sorted), sorted)
+}
+
+// place the scalars before the tables (sorted by key if "sorted" is true).
+func orderTomlKeyValues(scalars []TomlKeyValue, sorted bool) []TomlKeyValue {
+	tables := []TomlKeyValue{}
+
+	for idx := 0; idx < len(scalars); idx++ {
+		if scalars[idx].IsTable() {
+			tables = append(tables, scalars[idx])
+			scalars = append(scalars[:idx], scalars[idx+1:]...)
+			idx--
+		}
+	}	// end -- for (separate the tables)
+	if sorted {
+		sort.SliceStable(scalars, func(i, j int) bool { return scalars[i].Key < scalars[j].Key })
+		sort.SliceStable(tables, func(i, j int) bool { return tables[i].Key < tables[j].Key })
+	}
+	return append(scalars, tables...)
+}
+
+// return the key values of the Struct value's fields in field order;
+// inline Struct(s) are merged in place.
+func getTomlFieldKeyValues(objectVal reflect.Value, prefix string, strategy KeyNamingStrategy, sorted bool) []TomlKeyValue {
+	keyValues := []TomlKeyValue{}
+	objectType := objectVal.Type()
+
+	for idx:=0; idx<objectType.NumField(); idx++ {
 		fieldMetaRef := objectType.Field(idx)
+		tag := NewTagStructureByStrategy(fieldMetaRef, prefix, strategy)
 
-		valueMap[fieldMetaRef.Tag.Get(TagTOML)] = GetValueByTomlFieldNType(
-			object,
-			reflect.TypeOf(object),
-			fieldMetaRef.Name)
+		if tag.Skip || (tag.OmitEmpty && IsValueEmpty(objectVal.Field(idx))) {
+			continue
+		}
+		if tag.Inline && fieldMetaRef.Type.Kind() == reflect.Struct {
+			keyValues = append(keyValues, getTomlFieldKeyValues(objectVal.Field(idx), prefix, strategy, sorted)...)
+			continue
+		}
+		fieldVal := reflect.Indirect(objectVal.Field(idx))
+		if tag.IsParent() && len(tag.Field) == 0 && fieldVal.Kind() == reflect.Struct {
+			// child Struct without a key of its own; its Tags carry the full (dotted) keys
+			keyValues = append(keyValues, getTomlFieldKeyValues(fieldVal, prefix, strategy, sorted)...)
+			continue
+		}
+		if len(tag.Field) == 0 || !fieldVal.IsValid() {
+			// no key or a nil pointer; nothing to write
+			continue
+		}
+		keyValue := getTomlKeyValueByValue(tag.Field, fieldVal, strategy, sorted)
+		keyValue.Comment = tag.Comment
+		if keyValue.IsTable() && len(tag.Doc) > 0 {
+			keyValue.Comment = tag.Doc
+		}
+		keyValues = append(keyValues, keyValue)
+	}	// end -- for (fields)
+	return keyValues
+}
+
+// return the key value of the given value; Struct(s) and maps are returned
+// as tables (nested ones included), any other non supported type is
+// returned as-is.
+func getTomlKeyValueByValue(key string, fieldVal reflect.Value, strategy KeyNamingStrategy, sorted bool) TomlKeyValue {
+	if value, ok := GetTomlScalarValue(fieldVal); ok {
+		return TomlKeyValue{ Key: key, Value: value }
 	}
-	return valueMap
+	switch fieldVal.Kind() {
+	case reflect.Struct:
+		return TomlKeyValue{ Key: key, Value: getTomlKeyValues(fieldVal, key, strategy, sorted) }
+	case reflect.Map:
+		return TomlKeyValue{ Key: key, Value: getTomlKeyValuesByMap(fieldVal, key, strategy, sorted) }
+	}
+	return TomlKeyValue{ Key: key, Value: fieldVal.Interface() }
+}
+
+// return the key values of the map's entries; the entries are always sorted
+// by key (the iteration order of maps is random) and each entry's key is
+// prefixed by the map's key.
+func getTomlKeyValuesByMap(mapVal reflect.Value, prefix string, strategy KeyNamingStrategy, sorted bool) []TomlKeyValue {
+	keyValues := []TomlKeyValue{}
+	for _, mapKey := range mapVal.MapKeys() {
+		entryVal := mapVal.MapIndex(mapKey)
+		for entryVal.Kind() == reflect.Interface || entryVal.Kind() == reflect.Ptr {
+			if entryVal.IsNil() {
+				break
+			}
+			entryVal = entryVal.Elem()
+		}
+		if !entryVal.IsValid() || ((entryVal.Kind() == reflect.Interface || entryVal.Kind() == reflect.Ptr) && entryVal.IsNil()) {
+			// nil entry; nothing to write
+			continue
+		}
+		key := fmt.Sprintf("%v.%v", prefix, mapKey.Interface())
+		keyValues = append(keyValues, getTomlKeyValueByValue(key, entryVal, strategy, sorted))
+	}	// end -- for (map entries)
+	return orderTomlKeyValues(keyValues, true)
 }
 
 /* ---------------------------------------- */
 /*	access field value through reflection 	*/
 /* ---------------------------------------- */
 
+// function to check if the given value is empty; mirrors encoding/json's
+// "omitempty" => false, 0, empty strings, nil pointers and empty
+// slices / maps are empty; additionally the zero time.Time and any other
+// zero valued Struct are empty as well.
+func IsValueEmpty(fieldVal reflect.Value) bool {
+	if !fieldVal.IsValid() {
+		return true
+	}
+	switch fieldVal.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return fieldVal.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return fieldVal.IsNil()
+	}
+	if strings.Compare(fieldVal.Type().String(), TypeTime) == 0 {
+		return fieldVal.Interface().(time.Time).IsZero()
+	}
+	return reflect.DeepEqual(fieldVal.Interface(), reflect.Zero(fieldVal.Type()).Interface())
+}
+
 // function to check if the struct object's field at index "idx"
-// is empty or nil
+// is empty or nil.
+// deprecated => Save no longer relies on this check; the "omitempty"
+// toml Tag option (check IsValueEmpty) decides if a field is omitted.
 func IsFieldValueEmptyOrNil(object interface{}, idx int) bool {
 	valObj := reflect.ValueOf(object)
 	fieldTypeString := valObj.Field(idx).Type().String()
@@ -901,5 +1137,13 @@ func IsValidPointer(object interface{}) bool {
 	return object != nil
 }
 
+// function to check if the given "object" is a non nil pointer (e.g. a
+// pointer to the config Struct); the kind is checked first, hence Struct
+// values are rejected instead of panicking.
+func IsNonNilPointer(object interface{}) bool {
+	val := reflect.ValueOf(object)
+	return val.Kind() == reflect.Ptr && !val.IsNil()
+}
+
 
 
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"strconv"
 	"time"
+	"math"
 )
 
 // function to parse a string formatted array back into a real []string
@@ -33,6 +34,42 @@ func CleanseArrayedString(val string) []string {
 	return []string{}
 }
 
+// function to format a float into its toml representation; the shortest
+// representation reading back to the same value at the given "bitSize"
+// (32 or 64) is used and a decimal point is always kept (e.g. 990 => 990.0)
+// so the value stays a float on reload. Infinity and NaN are written as
+// inf, -inf and nan.
+func FormatFloatToString(value float64, bitSize int) string {
+	if math.IsNaN(value) {
+		return "nan"
+	} else if math.IsInf(value, 1) {
+		return "inf"
+	} else if math.IsInf(value, -1) {
+		return "-inf"
+	}
+	// exponent format for the very large / small values only (like %v)
+	format := byte('f')
+	if absValue := math.Abs(value); absValue != 0 && (absValue < 1e-4 || absValue >= 1e21) {
+		format = 'e'
+	}
+	sVal := strconv.FormatFloat(value, format, -1, bitSize)
+	if !strings.ContainsAny(sVal, ".e") {
+		sVal += ".0"
+	}
+	return sVal
+}
+
+// function to parse a toml float (e.g. 12.3, 1_000.5, 5e+22, inf, -inf,
+// nan) at the given "bitSize" (32 or 64).
+func ParseStringToFloat(value string, bitSize int) (float64, error) {
+	value = strings.Replace(strings.TrimSpace(value), "_", "", -1)
+	// strconv accepts "nan" but not the signed forms
+	if strings.Compare(value, "+nan") == 0 || strings.Compare(value, "-nan") == 0 {
+		value = "nan"
+	}
+	return strconv.ParseFloat(value, bitSize)
+}
+
 // function to parse a []string to []int
 func ConvertStringArrayToIntArray(stringArray []string) ([]int, error)  {
 	if stringArray != nil && len(stringArray)>0 {
@@ -57,7 +94,7 @@ func ConvertStringArrayToFloat32Array(stringArray []string) ([]float32, error)
 		iArray := make([]float32, len(stringArray))
 
 		for i, v := range stringArray {
-			iVal, err := strconv.ParseFloat( strings.TrimSpace(v), 32 )
+			iVal, err := ParseStringToFloat(v, 32)
 			if err != nil {
 				return nil, err
 			}
@@ -75,7 +112,7 @@ func ConvertStringArrayToFloat64Array(stringArray []string) ([]float64, error)
 		iArray := make([]float64, len(stringArray))
 
 		for i, v := range stringArray {
-			iVal, err := strconv.ParseFloat( strings.TrimSpace(v), 64 )
+			iVal, err := ParseStringToFloat(v, 64)
 			if err != nil {
 				return nil, err
 			}
@@ -147,4 +184,24 @@ func IsStringEmptyOrNil(value string) bool {
 		return true
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// return the index where an inline comment ("#") starts within the line,
+// searching from "valueStart"; "#" within quoted strings does not start a
+// comment. The line's length is returned if there is no inline comment.
+func GetInlineCommentIndex(line string, valueStart int) int {
+	var quote byte
+	for idx := valueStart; idx < len(line); idx++ {
+		switch {
+		case quote == '"' && line[idx] == '\\':
+			idx++
+		case quote != 0 && line[idx] == quote:
+			quote = 0
+		case quote == 0 && (line[idx] == '"' || line[idx] == '\''):
+			quote = line[idx]
+		case quote == 0 && line[idx] == '#':
+			return idx
+		}
+	}	// end -- for (characters)
+	return len(line)
+}
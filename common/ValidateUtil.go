@@ -0,0 +1,453 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// ValidateUtil contains field level validation related functions.
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/quoeamaster/CFactor/interfaces"
+)
+
+/*
+ *	basic tag format
+ *		validate:"rule1,rule2,..."
+ *
+ *		min=1           => numeric value >= 1 (string / slice => length >= 1)
+ *		max=65535       => numeric value <= 65535 (string / slice => length <= 65535)
+ *		oneof=admin user => value must be one of the space separated options
+ *		regex=^[a-z-]+$ => string value (or every member of a []string) must
+ *			match the pattern; MUST be the last rule as the pattern could
+ *			contain ","
+ *		len>0           => length of the string / slice; operators available
+ *			are =, !=, >, >=, < and <=
+ *
+ *	cross-field rules (the other field is referenced by its toml key)
+ *		required_if=tls.enabled true => value must be non empty if the
+ *			field "tls.enabled" has the value "true"
+ *		lte_field=limits.maxWorkers => value <= the field "limits.maxWorkers";
+ *			similarly lt_field, gte_field, gt_field, eq_field and ne_field
+ *
+ *	Struct level rules are implemented through the optional
+ *	interfaces.IConfigValidator hook (Validate() error).
+ */
+
+// the Tag's key indicating the validation rules for this Struct's field
+// (e.g. validate:"min=1,max=65535")
+const TagValidate = "validate"
+
+// validation rule "min"
+const RuleMin = "min"
+// validation rule "max"
+const RuleMax = "max"
+// validation rule "oneof"
+const RuleOneOf = "oneof"
+// validation rule "regex"
+const RuleRegex = "regex"
+// validation rule "len"
+const RuleLen = "len"
+// validation rule "required_if" (cross-field)
+const RuleRequiredIf = "required_if"
+// validation rule "eq_field" (cross-field)
+const RuleEqField = "eq_field"
+// validation rule "ne_field" (cross-field)
+const RuleNeField = "ne_field"
+// validation rule "lt_field" (cross-field)
+const RuleLtField = "lt_field"
+// validation rule "lte_field" (cross-field)
+const RuleLteField = "lte_field"
+// validation rule "gt_field" (cross-field)
+const RuleGtField = "gt_field"
+// validation rule "gte_field" (cross-field)
+const RuleGteField = "gte_field"
+
+// the "rule" name for failures reported by the IConfigValidator hook
+const RuleValidateHook = "Validate()"
+
+// operators for the cross-field comparison rules
+var fieldRuleOperators = map[string]string{
+	RuleEqField: "==",
+	RuleNeField: "!=",
+	RuleLtField: "<",
+	RuleLteField: "<=",
+	RuleGtField: ">",
+	RuleGteField: ">=",
+}
+
+// wraps a single validation failure
+type ValidationFailure struct {
+	// the toml key of the field (e.g. "author.age")
+	Key string
+	// line number (1-based) of the key within the config file; 0 if unknown
+	Line int
+	// the rule failed (e.g. "max=65535")
+	Rule string
+	// the field's value
+	Value interface{}
+	// description of the failure
+	Message string
+}
+
+// string presentation of a ValidationFailure
+func (f ValidationFailure) String() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("[%v] (line %v): %v", f.Key, f.Line, f.Message)
+	}
+	return fmt.Sprintf("[%v]: %v", f.Key, f.Message)
+}
+
+// error wrapping all the validation failures of a validation operation
+type ValidationError struct {
+	Failures []ValidationFailure
+}
+
+// return the description of all validation failures
+func (e *ValidationError) Error() string {
+	var bBuffer bytes.Buffer
+
+	bBuffer.WriteString(fmt.Sprintf("validation failed on %v field(s)", len(e.Failures)))
+	for _, failure := range e.Failures {
+		bBuffer.WriteString("\n\t")
+		bBuffer.WriteString(failure.String())
+	}
+	return bBuffer.String()
+}
+
+// validate the given Struct reference's fields based on the validate Tag.
+// Child Struct(s) (additional:"parent") are validated recursively; after
+// the Tag rules, the IConfigValidator hook is invoked bottom-up (child
+// Structs first and the "root" Struct last).
+// The "metaData" (optional, could be nil) provides the source line of the keys.
+// Returns a *ValidationError listing all failures (nil if everything is fine).
+func ValidateFieldValues(object interface{}, metaData *DecodeMetaData) error {
+	return ValidateFieldValuesByStrategy(object, metaData, NamingNone)
+}
+
+// validate the Struct's field values (same as ValidateFieldValues); fields
+// without a toml Tag are keyed by the key derived through the given naming
+// "strategy".
+func ValidateFieldValuesByStrategy(object interface{}, metaData *DecodeMetaData, strategy KeyNamingStrategy) error {
+	failures := []ValidationFailure{}
+
+	if IsValidPointer(object) {
+		rootVal := getAddressableValue(reflect.ValueOf(object))
+		failures = validateStructFields(rootVal, rootVal, "", strategy, metaData, failures)
+	}
+	if len(failures) > 0 {
+		return &ValidationError{ Failures: failures }
+	}
+	return nil
+}
+
+// return an addressable value of the given Struct (pointer or value), so
+// that hook methods with a pointer receiver could be invoked.
+func getAddressableValue(objVal reflect.Value) reflect.Value {
+	objVal = reflect.Indirect(objVal)
+	if objVal.IsValid() && !objVal.CanAddr() {
+		copyVal := reflect.New(objVal.Type()).Elem()
+		copyVal.Set(objVal)
+		return copyVal
+	}
+	return objVal
+}
+
+func validateStructFields(rootVal, objVal reflect.Value, structKey string, strategy KeyNamingStrategy, metaData *DecodeMetaData, failures []ValidationFailure) []ValidationFailure {
+	objVal = reflect.Indirect(objVal)
+	if objVal.Kind() != reflect.Struct {
+		return failures
+	}
+	objType := objVal.Type()
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag := NewTagStructureByStrategy(field, structKey, strategy)
+
+		if tag.Skip {
+			continue
+		} else if tag.IsParent() {
+			failures = validateStructFields(rootVal, objVal.Field(i), tag.Field, strategy, metaData, failures)
+			continue
+		} else if tag.Inline && field.Type.Kind() == reflect.Struct {
+			failures = validateStructFields(rootVal, objVal.Field(i), structKey, strategy, metaData, failures)
+			continue
+		}
+		rulesInString := field.Tag.Get(TagValidate)
+		if len(rulesInString) == 0 {
+			continue
+		}
+		key := tag.Field
+		if len(key) == 0 {
+			key = field.Name
+		}
+		line := 0
+		if metaData != nil {
+			line = metaData.GetLine(key)
+		}
+		for _, rule := range ParseValidateRules(rulesInString) {
+			msg := validateValueByRule(rootVal, objVal.Field(i), rule, strategy)
+			if len(msg) > 0 {
+				failures = append(failures, ValidationFailure{
+					Key: key,
+					Line: line,
+					Rule: rule,
+					Value: objVal.Field(i).Interface(),
+					Message: msg,
+				})
+			}
+		}	// end -- for (rules)
+	}	// end -- for (fields)
+
+	// Struct level validation hook (children are done already => bottom-up)
+	if err := invokeValidateHook(objVal); err != nil {
+		if len(structKey) == 0 {
+			structKey = objType.String()
+		}
+		failures = append(failures, ValidationFailure{
+			Key: structKey,
+			Line: 0,
+			Rule: RuleValidateHook,
+			Value: objVal.Interface(),
+			Message: err.Error(),
+		})
+	}
+	return failures
+}
+
+// invoke the IConfigValidator hook of the given Struct (if implemented).
+func invokeValidateHook(objVal reflect.Value) error {
+	if validator, ok := getHookTarget(objVal).(interfaces.IConfigValidator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// parse the validate Tag's value into individual rules; a "regex" rule
+// consumes the rest of the Tag's value.
+func ParseValidateRules(rulesInString string) []string {
+	rules := []string{}
+	remains := strings.TrimSpace(rulesInString)
+
+	for len(remains) > 0 {
+		if strings.Index(remains, RuleRegex+"=") == 0 {
+			rules = append(rules, remains)
+			break
+		}
+		idx := strings.Index(remains, ",")
+		if idx == -1 {
+			rules = append(rules, strings.TrimSpace(remains))
+			break
+		}
+		if rule := strings.TrimSpace(remains[:idx]); len(rule) > 0 {
+			rules = append(rules, rule)
+		}
+		remains = strings.TrimSpace(remains[idx+1:])
+	}	// end -- for (remains)
+	return rules
+}
+
+// validate the field's value by the given rule; returns the failure's
+// description or an empty string if the value is valid.
+// "rootVal" is the "root" Struct for resolving cross-field rules.
+func validateValueByRule(rootVal, fieldVal reflect.Value, rule string, strategy KeyNamingStrategy) string {
+	if strings.Index(rule, RuleLen) == 0 && !strings.HasPrefix(rule, RuleLen+"_") {
+		return validateLengthRule(fieldVal, rule[len(RuleLen):])
+	}
+	kv := strings.SplitN(rule, "=", 2)
+	if len(kv) != 2 {
+		return fmt.Sprintf("unknown validation rule {%v}", rule)
+	}
+	name, param := strings.TrimSpace(kv[0]), kv[1]
+
+	if op, ok := fieldRuleOperators[name]; ok {
+		return validateFieldComparisonRule(rootVal, fieldVal, rule, op, strings.TrimSpace(param), strategy)
+	}
+
+	switch name {
+	case RuleRequiredIf:
+		params := strings.Fields(param)
+		if len(params) != 2 {
+			return fmt.Sprintf("invalid parameter for rule {%v}; expected {%v=key value}", rule, RuleRequiredIf)
+		}
+		otherVal, ok := GetFieldValueByTomlKeyAndStrategy(rootVal, params[0], strategy)
+		if !ok {
+			return fmt.Sprintf("rule {%v} refers to an unknown key [%v]", rule, params[0])
+		}
+		if strings.Compare(fmt.Sprintf("%v", otherVal.Interface()), params[1]) == 0 && IsValueEmpty(fieldVal) {
+			return fmt.Sprintf("value is required when [%v] is %v", params[0], params[1])
+		}
+	case RuleMin, RuleMax:
+		limit, err := strconv.ParseFloat(strings.TrimSpace(param), 64)
+		if err != nil {
+			return fmt.Sprintf("invalid parameter for rule {%v}", rule)
+		}
+		value, isLength, ok := getComparableNumber(fieldVal)
+		if !ok {
+			return fmt.Sprintf("rule {%v} is not applicable to type [%v]", rule, fieldVal.Type())
+		}
+		if strings.Compare(name, RuleMin) == 0 && value < limit {
+			if isLength {
+				return fmt.Sprintf("length %v is less than the minimum %v", value, param)
+			}
+			return fmt.Sprintf("value %v is less than the minimum %v", fieldVal.Interface(), param)
+		}
+		if strings.Compare(name, RuleMax) == 0 && value > limit {
+			if isLength {
+				return fmt.Sprintf("length %v is greater than the maximum %v", value, param)
+			}
+			return fmt.Sprintf("value %v is greater than the maximum %v", fieldVal.Interface(), param)
+		}
+	case RuleOneOf:
+		options := strings.Fields(param)
+		sVal := fmt.Sprintf("%v", fieldVal.Interface())
+		for _, option := range options {
+			if strings.Compare(option, sVal) == 0 {
+				return ""
+			}
+		}
+		return fmt.Sprintf("value [%v] is not one of %v", sVal, options)
+	case RuleRegex:
+		pattern, err := regexp.Compile(param)
+		if err != nil {
+			return fmt.Sprintf("invalid pattern for rule {%v} => %v", rule, err)
+		}
+		if fieldVal.Kind() == reflect.String {
+			if !pattern.MatchString(fieldVal.String()) {
+				return fmt.Sprintf("value [%v] does not match the pattern {%v}", fieldVal.String(), param)
+			}
+		} else if strings.Compare(fieldVal.Type().String(), TypeArrayString) == 0 {
+			for idx, sVal := range fieldVal.Interface().([]string) {
+				if !pattern.MatchString(sVal) {
+					return fmt.Sprintf("value [%v] at index %v does not match the pattern {%v}", sVal, idx, param)
+				}
+			}
+		} else {
+			return fmt.Sprintf("rule {%v} is not applicable to type [%v]", rule, fieldVal.Type())
+		}
+	default:
+		return fmt.Sprintf("unknown validation rule {%v}", rule)
+	}
+	return ""
+}
+
+// validate the length of a string / slice; "expression" is the rule
+// without the "len" prefix (e.g. ">0" or "=3").
+func validateLengthRule(fieldVal reflect.Value, expression string) string {
+	kind := fieldVal.Kind()
+	if !(kind == reflect.String || kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map) {
+		return fmt.Sprintf("rule {%v%v} is not applicable to type [%v]", RuleLen, expression, fieldVal.Type())
+	}
+	length := fieldVal.Len()
+
+	for _, op := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.Index(expression, op) != 0 {
+			continue
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(expression[len(op):]))
+		if err != nil {
+			return fmt.Sprintf("invalid parameter for rule {%v%v}", RuleLen, expression)
+		}
+		if !compareNumbers(float64(length), op, float64(limit)) {
+			return fmt.Sprintf("length %v does not satisfy {%v%v}", length, RuleLen, expression)
+		}
+		return ""
+	}	// end -- for (operators)
+	return fmt.Sprintf("unknown validation rule {%v%v}", RuleLen, expression)
+}
+
+// validate the field's value against another field's value (cross-field
+// rules such as lte_field); numeric fields compare their values, time.Time
+// fields compare their instants and the rest compare their string presentations.
+func validateFieldComparisonRule(rootVal, fieldVal reflect.Value, rule, op, otherKey string, strategy KeyNamingStrategy) string {
+	otherVal, ok := GetFieldValueByTomlKeyAndStrategy(rootVal, otherKey, strategy)
+	if !ok {
+		return fmt.Sprintf("rule {%v} refers to an unknown key [%v]", rule, otherKey)
+	}
+	if left, ok := getTimeValue(fieldVal); ok {
+		right, ok2 := getTimeValue(otherVal)
+		if !ok2 {
+			return fmt.Sprintf("rule {%v} could not compare [%v] with [%v]", rule, fieldVal.Type(), otherVal.Type())
+		}
+		if !compareNumbers(float64(left.Sub(right)), op, 0) {
+			return fmt.Sprintf("value %v is not %v [%v] (%v)", FormatTimeToString("", left), op, otherKey, FormatTimeToString("", right))
+		}
+		return ""
+	}
+	left, isLength, ok := getComparableNumber(fieldVal)
+	right, isLength2, ok2 := getComparableNumber(otherVal)
+	if ok && ok2 && !isLength && !isLength2 {
+		if !compareNumbers(left, op, right) {
+			return fmt.Sprintf("value %v is not %v [%v] (%v)", fieldVal.Interface(), op, otherKey, otherVal.Interface())
+		}
+		return ""
+	}
+	if strings.Compare(op, "==") != 0 && strings.Compare(op, "!=") != 0 {
+		return fmt.Sprintf("rule {%v} could not compare [%v] with [%v]", rule, fieldVal.Type(), otherVal.Type())
+	}
+	isEqual := reflect.DeepEqual(fieldVal.Interface(), otherVal.Interface())
+	if isEqual != (strings.Compare(op, "==") == 0) {
+		return fmt.Sprintf("value %v is not %v [%v] (%v)", fieldVal.Interface(), op, otherKey, otherVal.Interface())
+	}
+	return ""
+}
+
+// return the time.Time value of the field (if it is a time.Time field).
+func getTimeValue(fieldVal reflect.Value) (time.Time, bool) {
+	if fieldVal.IsValid() && strings.Compare(fieldVal.Type().String(), TypeTime) == 0 {
+		return fieldVal.Interface().(time.Time), true
+	}
+	return time.Time{}, false
+}
+
+// compare 2 numbers with the given operator (=, ==, !=, >, >=, < and <=).
+func compareNumbers(left float64, op string, right float64) bool {
+	switch op {
+	case "=", "==":
+		return left == right
+	case "!=":
+		return left != right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	}
+	return false
+}
+
+// return the number to compare for the min / max rules; numeric fields
+// return their values and string / slice fields return their lengths.
+func getComparableNumber(fieldVal reflect.Value) (value float64, isLength bool, ok bool) {
+	switch fieldVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fieldVal.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fieldVal.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return fieldVal.Float(), false, true
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(fieldVal.Len()), true, true
+	}
+	return 0, false, false
+}
@@ -17,24 +17,37 @@
 // package defining the common interface(s)
 package interfaces
 
-import "reflect"
+import (
+	"io"
+)
 
-// declare the interface of a configurable object.
-// able to Load config data from the config file to a
-// targeted Struct instance;
-// able to Save changes to a config file
+// declare the format neutral interface of a config backend (e.g.
+// TOML.TOMLConfigImpl); able to Load config data from the config file (or
+// a stream) into a targeted Struct instance and able to Save the Struct's
+// values back.
+//
+// the options of a backend (e.g. naming strategy, sorted keys, backups)
+// are set through the implementation's exported fields and are honoured by
+// every method below. The lifecycle hooks and validations (check below)
+// are run by all of them as well.
 type IConfig interface {
-	// able to load a configuration file and populate the values into the
-	// targeted Struct reference.
-	// Return a generic reflect.Value (casting is possible) and
-	// the error occurred during the Load operation.
-	Load(configFilenameOrPath string, structType reflect.Type) (reflect.Value, error)
+	// able to load the backend's configuration file and populate the values
+	// into the targeted Struct reference (a pointer).
+	// Return the same Struct reference and the error occurred during the
+	// Load operation.
+	Load(ptrConfigObject interface{}) (interface{}, error)
 
-	// able to persist the given object reference's values back into the
-	// targeted configuration file.
-	Save(configFilenameOrPath string, structType reflect.Type, configObject interface{}) (error)
-}
+	// able to persist the given object's values (a Struct value or a
+	// pointer) back into the targeted configuration file; the Struct's type
+	// is derived from the object.
+	Save(configFilenameOrPath string, configObject interface{}) (error)
+
+	// same as Load but the configuration is read from the given reader.
+	Decode(reader io.Reader, ptrConfigObject interface{}) (error)
 
+	// same as Save but the configuration is written to the given writer.
+	Encode(writer io.Writer, configObject interface{}) (error)
+}
 
 // declare the interface for the lifecycle hook functions.
 type IConfigLifeCycleHooks interface {
@@ -42,30 +55,63 @@ type IConfigLifeCycleHooks interface {
 	// (containing fields pointing to another Struct).
 	// The "parent" Struct would need to handle the logic to safely set back
 	// the child Struct(s).
-	// This function acts as the lifecycle hook.
+	// This function acts as the lifecycle hook and is optional; if not
+	// implemented, the child Struct(s) are set back through reflection.
 	SetStructsReferences(structRefMap *map[string]interface{}) (error)
 }
 
-// declaring the lifecycle hook function's name on
-// "hierarchical Struct setting"
-const MethodSetStructsReference = "SetStructsReferences"
-
-
-/**
- *	include a generic set method.
- *	provide the "key" plus an optional "params" map
+/*
+ *	optional lifecycle hooks; detected through type assertion and invoked on
+ *	the "root" Struct plus every child Struct (additional:"parent").
+ *
+ *	order of invocation
+ *		BeforeLoad / BeforeSave => top-down (the "root" Struct first, then
+ *			the child Structs in field order)
+ *		AfterLoad / AfterSave => bottom-up (the child Structs first in field
+ *			order, the "root" Struct last)
+ *
+ *	Load => BeforeLoad, populate values, AfterLoad, validation
+ *	Save => BeforeSave, validation, persist values, AfterSave
  *
- *	implementation would need to handle implicit in the code (eg. date format
- *	handling and type casting into time.Time)
+ *	the first error returned by a hook stops the operation.
  */
-/*
-type ISetter interface {
-	set(key string, params map[string]string) (bool, error)
+
+// declare the interface for the "before load" lifecycle hook.
+type IConfigBeforeLoad interface {
+	// invoked before the config values are populated (e.g. reset states).
+	BeforeLoad() (error)
 }
 
+// declare the interface for the "after load" lifecycle hook.
+type IConfigAfterLoad interface {
+	// invoked after the config values are populated and before validation
+	// (e.g. normalise values such as lower-casing hostnames).
+	AfterLoad() (error)
+}
+
+// declare the interface for the "before save" lifecycle hook.
+type IConfigBeforeSave interface {
+	// invoked before the config values are validated and persisted
+	// (e.g. derive fields).
+	BeforeSave() (error)
+}
 
-type IGetter interface {
-	Get(key string) (interface{})
+// declare the interface for the "after save" lifecycle hook.
+type IConfigAfterSave interface {
+	// invoked after the config values are persisted.
+	AfterSave() (error)
 }
-*/
 
+// declaring the lifecycle hook function's name on
+// "hierarchical Struct setting"
+const MethodSetStructsReference = "SetStructsReferences"
+
+// declare the interface for the (optional) validation hook function.
+type IConfigValidator interface {
+	// validate the Struct as a whole; useful for rules spanning multiple
+	// fields (e.g. "minWorkers <= maxWorkers").
+	// This function acts as the lifecycle hook and is invoked bottom-up on
+	// every child Struct and finally the "root" Struct; after the values are
+	// loaded and before the values are saved.
+	Validate() (error)
+}
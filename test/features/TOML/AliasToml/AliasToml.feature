@@ -0,0 +1,30 @@
+Feature: TOML key aliases and deprecated keys
+  Renamed keys could keep their old names through the alias Tag
+  (alias:"workingHoursDay"); the deprecated Tag provides a migration hint
+  (deprecated:"use work.hoursPerDay"). Deprecated keys still populate the
+  field, a warning callback is invoked with the file and line, and Save
+  writes the new key only.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - old keys populate the renamed fields
+  - a warning is raised for every deprecated key found
+  - Save migrates the old keys to the new ones
+
+  Scenario: 1) Load with the old keys
+    Given there is a TOML in the current folder named "aliasToml.toml"
+    When I load the TOML file named "aliasToml.toml"
+    Then the values loaded are "8,5,true,HQ"
+    And the key "work.hoursPerDay" is defined at line 1
+    And the warnings raised are "aliasToml.toml:1: key [workingHoursDay] is deprecated; use work.hoursPerDay|aliasToml.toml:2: key [daysPerWeek] is deprecated; use work.daysPerWeek|aliasToml.toml:3: key [work.overtime] is deprecated; overtime is no longer configurable"
+
+  Scenario: 2) Save migrates to the new keys
+    Given there is a TOML in the current folder named "aliasToml.toml"
+    When I load the TOML file named "aliasToml.toml"
+    And save changes to the "aliasToml_test.toml"
+    Then the file "aliasToml_test.toml" contains the keys "work.hoursPerDay,work.daysPerWeek"
+    And the file "aliasToml_test.toml" does not contain the keys "workingHoursDay,daysPerWeek"
+    And finally reload the configuration file "aliasToml_test.toml", the values loaded are "8,5,true,HQ" with 1 warnings
@@ -0,0 +1,162 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the key aliases and deprecated keys
+package AliasToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.WorkConfig
+var metaData common.DecodeMetaData
+var warnings []string
+
+func gotTomlFileName(tomlFile string) error {
+	if len(tomlFile)>0 {
+		configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.WorkConfig{}))
+		configReader.OnDeprecatedKey = func(warning common.DeprecationWarning) {
+			warnings = append(warnings, warning.String())
+		}
+		return nil
+	}
+	return fmt.Errorf("the given 'name' is not Valid (%v)", tomlFile)
+}
+
+func loadTomlFile(_ string) error {
+	var err error
+	configObject = TOML2.WorkConfig{}
+	warnings = []string{}
+
+	_, metaData, err = configReader.LoadWithMetaData(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func getLoadedValues(object TOML2.WorkConfig) string {
+	return fmt.Sprintf("%v,%v,%v,%v", object.HoursPerDay, object.DaysPerWeek, object.Overtime, object.Office)
+}
+
+func theValuesLoadedAre(values string) error {
+	if actual := getLoadedValues(configObject); strings.Compare(actual, values) != 0 {
+		return fmt.Errorf("expected values [%v] but got [%v]", values, actual)
+	}
+	return nil
+}
+
+func theKeyIsDefinedAtLine(key string, line int) error {
+	if !metaData.IsDefined(key) || metaData.GetLine(key) != line {
+		return fmt.Errorf("the key [%v] should be defined at line %v; defined keys => %v", key, line, metaData.Defined)
+	}
+	return nil
+}
+
+func theWarningsRaisedAre(expected string) error {
+	if actual := strings.Join(warnings, "|"); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected warnings [%v] but got [%v]", expected, actual)
+	}
+	return nil
+}
+
+func saveChangesToToml(tomlFile string) error {
+	err := configReader.Save(tomlFile, configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+// return the keys declared in the given toml file
+func getKeysInFile(filename string) (map[string]bool, error) {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	for _, line := range strings.Split(string(bContent), "\n") {
+		if idx := strings.Index(line, "="); idx > 0 {
+			keys[strings.TrimSpace(line[:idx])] = true
+		}
+	}
+	return keys, nil
+}
+
+func theFileContainsTheKeys(filename, keys string) error {
+	keysInFile, err := getKeysInFile(filename)
+	if err != nil {
+		return err
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if !keysInFile[key] {
+			return fmt.Errorf("the key [%v] should be saved to [%v]", key, filename)
+		}
+	}
+	return nil
+}
+
+func theFileDoesNotContainTheKeys(filename, keys string) error {
+	keysInFile, err := getKeysInFile(filename)
+	if err != nil {
+		return err
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if keysInFile[key] {
+			return fmt.Errorf("the key [%v] should NOT be saved to [%v]", key, filename)
+		}
+	}
+	return nil
+}
+
+func reconciliationOnValues(filename, values string, warningCount int) error {
+	configReader.Name = filename
+	configObject2 := TOML2.WorkConfig{}
+	warnings = []string{}
+
+	if _, err := configReader.Load(&configObject2); err != nil {
+		return fmt.Errorf("something wrong when loading the config file %v => %v\n", filename, err)
+	}
+	if actual := getLoadedValues(configObject2); strings.Compare(actual, values) != 0 {
+		return fmt.Errorf("expected values [%v] BUT have [%v]\n", values, actual)
+	}
+	if len(warnings) != warningCount {
+		return fmt.Errorf("expected %v warnings BUT have %v\n", warningCount, warnings)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, gotTomlFileName)
+	s.Step(`^I load the TOML file named "([^"]*)"$`, loadTomlFile)
+	s.Step(`^the values loaded are "([^"]*)"$`, theValuesLoadedAre)
+	s.Step(`^the key "([^"]*)" is defined at line (\d+)$`, theKeyIsDefinedAtLine)
+	s.Step(`^the warnings raised are "([^"]*)"$`, theWarningsRaisedAre)
+	s.Step(`^save changes to the "([^"]*)"$`, saveChangesToToml)
+	s.Step(`^the file "([^"]*)" contains the keys "([^"]*)"$`, theFileContainsTheKeys)
+	s.Step(`^the file "([^"]*)" does not contain the keys "([^"]*)"$`, theFileDoesNotContainTheKeys)
+	s.Step(`^finally reload the configuration file "([^"]*)", the values loaded are "([^"]*)" with (\d+) warnings$`, reconciliationOnValues)
+}
@@ -0,0 +1,39 @@
+Feature: TOML atomic save with backups
+  Save writes the contents into a temp file in the same folder, syncs it
+  and renames it over the config file; a failed Save never leaves a
+  truncated config behind. The mode of the config file is kept and
+  TOMLConfigImpl.Backups rotated copies of the previous contents are kept.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+  - the file is copied before the test as it is written back
+
+  Major use cases:
+  - the file mode is kept; no temp file is left in the folder
+  - the previous contents are rotated into .bak.1 ... .bak.N (written
+    atomically as well)
+
+  Scenario: 1) Save with rotated backups
+    Given a copy of "atomicSaveToml.toml" named "atomicSaveToml_test.toml" with the mode "0600"
+    When I load the TOML file named "atomicSaveToml_test.toml" keeping 2 backups
+    And save the role "user"
+    And save the role "guest"
+    And save the role "owner"
+    Then the role in the file "atomicSaveToml_test.toml" is "owner"
+    And the role in the file "atomicSaveToml_test.toml.bak.1" is "guest"
+    And the role in the file "atomicSaveToml_test.toml.bak.2" is "user"
+    And the file "atomicSaveToml_test.toml.bak.3" does not exist
+    And the mode of the file "atomicSaveToml_test.toml" is "0600"
+    And the mode of the file "atomicSaveToml_test.toml.bak.1" is "0600"
+    And no temp file is left in the folder
+    And the backups of "atomicSaveToml_test.toml" are removed
+
+  Scenario: 2) Save without backups
+    Given a copy of "atomicSaveToml.toml" named "atomicSaveTomlNoBackup_test.toml" with the mode "0640"
+    When I load the TOML file named "atomicSaveTomlNoBackup_test.toml" keeping 0 backups
+    And save the role "user"
+    Then the role in the file "atomicSaveTomlNoBackup_test.toml" is "user"
+    And the file "atomicSaveTomlNoBackup_test.toml.bak.1" does not exist
+    And the mode of the file "atomicSaveTomlNoBackup_test.toml" is "0640"
+    And no temp file is left in the folder
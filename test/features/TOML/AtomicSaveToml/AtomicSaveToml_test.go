@@ -0,0 +1,135 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the atomic save with backups
+package AtomicSaveToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.DemoTOMLConfig
+
+func aCopyOfNamedWithTheMode(source, target, mode string) error {
+	bContent, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	fileMode, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(target, bContent, os.FileMode(fileMode)); err != nil {
+		return err
+	}
+	// WriteFile applies the umask (new files only)
+	return os.Chmod(target, os.FileMode(fileMode))
+}
+
+func loadTomlFileKeepingBackups(tomlFile string, backups int) error {
+	configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.DemoTOMLConfig{}))
+	configReader.Backups = backups
+	configObject = TOML2.DemoTOMLConfig{}
+
+	_, err := configReader.Load(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func saveTheRole(role string) error {
+	configObject.Role = role
+	err := configReader.Save(configReader.Name, configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+func theRoleInTheFileIs(filename, role string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	expected := fmt.Sprintf("role = \"%v\"", role)
+	if !strings.Contains(string(bContent), expected) {
+		return fmt.Errorf("expected [%v] in the file [%v]; got =>\n%v", expected, filename, string(bContent))
+	}
+	return nil
+}
+
+func theFileDoesNotExist(filename string) error {
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		return fmt.Errorf("expected the file [%v] not exist", filename)
+	}
+	return nil
+}
+
+func theModeOfTheFileIs(filename, mode string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	if actual := fmt.Sprintf("%04o", info.Mode().Perm()); strings.Compare(actual, mode) != 0 {
+		return fmt.Errorf("expected the mode of [%v] to be [%v]; got [%v]", filename, mode, actual)
+	}
+	return nil
+}
+
+func noTempFileIsLeftInTheFolder() error {
+	files, err := ioutil.ReadDir(".")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") && strings.Contains(file.Name(), ".tmp") {
+			return fmt.Errorf("temp file [%v] is left in the folder", file.Name())
+		}
+	}
+	return nil
+}
+
+func theBackupsOfAreRemoved(filename string) error {
+	for idx := 1; idx <= configReader.Backups; idx++ {
+		os.Remove(common.GetBackupFilename(filename, idx))
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^a copy of "([^"]*)" named "([^"]*)" with the mode "([^"]*)"$`, aCopyOfNamedWithTheMode)
+	s.Step(`^I load the TOML file named "([^"]*)" keeping (\d+) backups$`, loadTomlFileKeepingBackups)
+	s.Step(`^save the role "([^"]*)"$`, saveTheRole)
+	s.Step(`^the role in the file "([^"]*)" is "([^"]*)"$`, theRoleInTheFileIs)
+	s.Step(`^the file "([^"]*)" does not exist$`, theFileDoesNotExist)
+	s.Step(`^the mode of the file "([^"]*)" is "([^"]*)"$`, theModeOfTheFileIs)
+	s.Step(`^no temp file is left in the folder$`, noTempFileIsLeftInTheFolder)
+	s.Step(`^the backups of "([^"]*)" are removed$`, theBackupsOfAreRemoved)
+}
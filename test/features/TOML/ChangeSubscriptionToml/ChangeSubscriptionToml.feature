@@ -0,0 +1,31 @@
+Feature: TOML per key change subscriptions
+  Store.OnChange subscribes to the changes of a key (e.g. "client.address.city")
+  or the keys under a prefix (e.g. "broker.*"); after a successful Load / Swap
+  the callbacks are invoked once per changed key with the old and new values.
+
+  Assumptions for the feature test:
+  - in-memory records are published through Store.Swap
+  - the config file is reloaded through Store.Load
+
+  Major use cases:
+  - key and prefix subscriptions receive the old and new values
+  - unchanged keys and the initial snapshot fire no callbacks
+  - a reload of the config file fires the callbacks of the changed keys
+
+  Scenario: 1) Key and prefix subscriptions
+    Given a Store of transaction records
+    And the subscriptions "client.address.city, broker.*, amount"
+    When a record in "Seoul" with the broker id "B-001" is swapped in
+    Then no change is notified
+    When a record in "Busan" with the broker id "B-002" is swapped in
+    Then the changes notified are "client.address.city: Seoul => Busan; broker.*: B-001 => B-002"
+    When a record in "Busan" with the broker id "B-002" is swapped in
+    Then no change is notified
+
+  Scenario: 2) Reload of the config file
+    Given a Store of the config file "changeSubscriptionToml.toml"
+    And the subscriptions "port, tls.*, limits.maxWorkers, hostname"
+    When the Store is loaded
+    Then no change is notified
+    When the config file is replaced by "changeSubscriptionTomlUpdated.toml" and reloaded
+    Then the changes notified are "port: 8080 => 9090; limits.maxWorkers: 16 => 8; tls.*: true => false; tls.*: /etc/ssl/order-service.crt => ; tls.*: /etc/ssl/order-service.key => "
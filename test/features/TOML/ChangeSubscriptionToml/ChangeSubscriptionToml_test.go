@@ -0,0 +1,129 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the per key change subscriptions
+package ChangeSubscriptionToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+// the subscribe function of the Store under test
+var subscribe func(pattern string, callback func(oldValue, newValue interface{}))
+var recordStore *TOML.Store[TOML2.TransactionRecord]
+var serverStore *TOML.Store[TOML2.ServerConfig]
+var configFile string
+var changes []string
+
+func aStoreOfTransactionRecords() error {
+	recordStore = TOML.NewStore[TOML2.TransactionRecord]("transactionRecord.toml")
+	subscribe = recordStore.OnChange
+	changes = []string{}
+	return nil
+}
+
+func aStoreOfTheConfigFile(fixture string) error {
+	dir, err := os.MkdirTemp("", "changeSubscriptionToml")
+	if err != nil {
+		return err
+	}
+	configFile = filepath.Join(dir, "config.toml")
+	if err := replaceConfigFile(fixture); err != nil {
+		return err
+	}
+	serverStore = TOML.NewStore[TOML2.ServerConfig](configFile)
+	subscribe = serverStore.OnChange
+	changes = []string{}
+	return nil
+}
+
+func replaceConfigFile(fixture string) error {
+	bBytes, err := common.LoadFile(fixture)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFile, bBytes, 0644)
+}
+
+func theSubscriptions(patterns string) error {
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		subscribe(pattern, func(oldValue, newValue interface{}) {
+			changes = append(changes, fmt.Sprintf("%v: %v => %v", pattern, oldValue, newValue))
+		})
+	}
+	return nil
+}
+
+func aRecordInWithTheBrokerIdIsSwappedIn(city, brokerId string) error {
+	record := TOML2.TransactionRecord{}
+	record.Amount = 2359.91
+	record.Client.FullName = "Jackie Kim"
+	record.Client.Address.City = city
+	record.Broker.Id = brokerId
+	record.Broker.Licences = []string{ "audit-approved" }
+	_, err := recordStore.Swap(&record)
+	return err
+}
+
+func theStoreIsLoaded() error {
+	_, err := serverStore.Load()
+	return err
+}
+
+func theConfigFileIsReplacedByAndReloaded(fixture string) error {
+	if err := replaceConfigFile(fixture); err != nil {
+		return err
+	}
+	_, err := serverStore.Load()
+	return err
+}
+
+func noChangeIsNotified() error {
+	if len(changes) > 0 {
+		return fmt.Errorf("expected no change; got %v", changes)
+	}
+	return nil
+}
+
+func theChangesNotifiedAre(expected string) error {
+	actual := strings.Join(changes, "; ")
+	changes = []string{}
+	if strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected the changes [%v]; got [%v]", expected, actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^a Store of transaction records$`, aStoreOfTransactionRecords)
+	s.Step(`^a Store of the config file "([^"]*)"$`, aStoreOfTheConfigFile)
+	s.Step(`^the subscriptions "([^"]*)"$`, theSubscriptions)
+	s.Step(`^a record in "([^"]*)" with the broker id "([^"]*)" is swapped in$`, aRecordInWithTheBrokerIdIsSwappedIn)
+	s.Step(`^the Store is loaded$`, theStoreIsLoaded)
+	s.Step(`^the config file is replaced by "([^"]*)" and reloaded$`, theConfigFileIsReplacedByAndReloaded)
+	s.Step(`^no change is notified$`, noChangeIsNotified)
+	s.Step(`^the changes notified are "([^"]*)"$`, theChangesNotifiedAre)
+}
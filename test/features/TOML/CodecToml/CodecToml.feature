@@ -0,0 +1,30 @@
+Feature: TOML Marshal / Unmarshal and streaming Decoder / Encoder
+  TOML.Unmarshal and TOML.Marshal convert between []byte and Struct(s);
+  NewDecoder and NewEncoder do the same on an io.Reader / io.Writer with
+  options (naming strategy, sorted keys, dotted keys etc). Load and Save
+  are built on top of them, hence the hooks and validations are the same.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - decode contents not coming from a config file (e.g. embedded resources)
+  - encode into a buffer instead of a file
+
+  Scenario: 1) Unmarshal and Marshal
+    Given the contents of the file "codecToml.toml"
+    When I unmarshal the contents
+    Then the name is "order-service" and the max workers is 16
+    And marshalling the config equals to the file "codecTomlExpected.toml"
+
+  Scenario: 2) Decoder and Encoder with options
+    Given the contents of the file "codecToml.toml"
+    When I decode the contents through a Decoder
+    Then the name is "order-service" and the max workers is 16
+    And encoding the config with dotted keys equals to the file "codecTomlDottedExpected.toml"
+
+  Scenario: 3) Invalid values are rejected
+    Given the contents of the file "codecToml.toml"
+    Then unmarshalling the contents into a non pointer fails
+    And marshalling a config with the role "guest" fails
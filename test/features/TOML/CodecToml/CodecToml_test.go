@@ -0,0 +1,120 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the Marshal / Unmarshal functions and the Decoder / Encoder
+package CodecToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var contents []byte
+var configObject TOML2.ServerConfig
+
+func theContentsOfTheFile(tomlFile string) error {
+	var err error
+	contents, err = ioutil.ReadFile(tomlFile)
+	return err
+}
+
+func unmarshalTheContents() error {
+	configObject = TOML2.ServerConfig{}
+	return TOML.Unmarshal(contents, &configObject)
+}
+
+func decodeTheContentsThroughADecoder() error {
+	configObject = TOML2.ServerConfig{}
+	return TOML.NewDecoder(bytes.NewReader(contents)).Decode(&configObject)
+}
+
+func theNameIsAndTheMaxWorkersIs(name string, maxWorkers int) error {
+	if strings.Compare(configObject.Name, name) != 0 {
+		return fmt.Errorf("expected name [%v]; got [%v]", name, configObject.Name)
+	}
+	if configObject.Limits.MaxWorkers != maxWorkers {
+		return fmt.Errorf("expected max workers [%v]; got [%v]", maxWorkers, configObject.Limits.MaxWorkers)
+	}
+	return nil
+}
+
+func marshallingTheConfigEqualsToTheFile(expectedFilename string) error {
+	bContent, err := TOML.Marshal(configObject)
+	if err != nil {
+		return err
+	}
+	return contentEqualsToTheFile(bContent, expectedFilename)
+}
+
+func encodingTheConfigWithDottedKeysEqualsToTheFile(expectedFilename string) error {
+	var bBuffer bytes.Buffer
+	encoder := TOML.NewEncoder(&bBuffer)
+	encoder.DottedKeys = true
+	if err := encoder.Encode(&configObject); err != nil {
+		return err
+	}
+	return contentEqualsToTheFile(bBuffer.Bytes(), expectedFilename)
+}
+
+func unmarshallingTheContentsIntoANonPointerFails() error {
+	err := TOML.Unmarshal(contents, TOML2.ServerConfig{})
+	if err == nil || !strings.Contains(err.Error(), "pointer") {
+		return fmt.Errorf("expected a pointer required error on unmarshalling into a non pointer; got [%v]", err)
+	}
+	return nil
+}
+
+func marshallingAConfigWithTheRoleFails(role string) error {
+	config := TOML2.ServerConfig{}
+	if err := TOML.Unmarshal(contents, &config); err != nil {
+		return err
+	}
+	config.Role = role
+	if bContent, err := TOML.Marshal(config); err == nil {
+		return fmt.Errorf("expected a validation error on the role [%v]; got =>\n%v", role, string(bContent))
+	}
+	return nil
+}
+
+func contentEqualsToTheFile(bContent []byte, expectedFilename string) error {
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the contents differ from [%v]; got =>\n%v", expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^the contents of the file "([^"]*)"$`, theContentsOfTheFile)
+	s.Step(`^I unmarshal the contents$`, unmarshalTheContents)
+	s.Step(`^I decode the contents through a Decoder$`, decodeTheContentsThroughADecoder)
+	s.Step(`^the name is "([^"]*)" and the max workers is (\d+)$`, theNameIsAndTheMaxWorkersIs)
+	s.Step(`^marshalling the config equals to the file "([^"]*)"$`, marshallingTheConfigEqualsToTheFile)
+	s.Step(`^encoding the config with dotted keys equals to the file "([^"]*)"$`, encodingTheConfigWithDottedKeysEqualsToTheFile)
+	s.Step(`^unmarshalling the contents into a non pointer fails$`, unmarshallingTheContentsIntoANonPointerFails)
+	s.Step(`^marshalling a config with the role "([^"]*)" fails$`, marshallingAConfigWithTheRoleFails)
+}
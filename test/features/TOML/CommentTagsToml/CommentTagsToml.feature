@@ -0,0 +1,24 @@
+Feature: TOML comments from Struct Tags on save
+  Save writes the comment:"..." Tag of a field as # comment lines above
+  its key and the doc:"..." Tag of a child Struct (or map) above its
+  [table] header; generated config files hence explain themselves.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - comments above the keys (multi-line comments included)
+  - doc comments above the [table] headers (or the dotted keys group)
+
+  Scenario: 1) Save with [table] sections
+    Given an in-memory worker pool config
+    When I save the config to "commentTagsToml_test.toml"
+    Then the file "commentTagsToml_test.toml" equals to the file "commentTagsTomlExpected.toml"
+    And loading "commentTagsToml_test.toml" yields the same config
+
+  Scenario: 2) Save with dotted keys
+    Given an in-memory worker pool config
+    When I save the config with dotted keys to "commentTagsTomlDotted_test.toml"
+    Then the file "commentTagsTomlDotted_test.toml" equals to the file "commentTagsTomlDottedExpected.toml"
+    And loading "commentTagsTomlDotted_test.toml" yields the same config
@@ -0,0 +1,98 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the comment:"..." and doc:"..." Tags written on save
+package CommentTagsToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configObject TOML2.WorkerPoolConfig
+
+func anInMemoryWorkerPoolConfig() error {
+	configObject = TOML2.WorkerPoolConfig{
+		Name: "jobs",
+		Port: 9090,
+		Pool: TOML2.WorkerPool{
+			MaxWorkers: 16,
+			IdleTimeout: 30,
+			Retry: TOML2.WorkerRetry{ Attempts: 3, Backoff: 1.5 },
+		},
+		Queues: map[string]int{ "emails": 100, "reports": 10 },
+	}
+	return nil
+}
+
+func saveTheConfigTo(tomlFile string) error {
+	return saveConfig(tomlFile, false)
+}
+
+func saveTheConfigWithDottedKeysTo(tomlFile string) error {
+	return saveConfig(tomlFile, true)
+}
+
+func saveConfig(tomlFile string, dottedKeys bool) error {
+	configReader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.WorkerPoolConfig{}))
+	configReader.DottedKeys = dottedKeys
+	return configReader.Save(tomlFile, configObject)
+}
+
+func theFileEqualsToTheFile(filename, expectedFilename string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the file [%v] differs from [%v]; got =>\n%v", filename, expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+func loadingYieldsTheSameConfig(tomlFile string) error {
+	loaded := TOML2.WorkerPoolConfig{}
+	configReader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.WorkerPoolConfig{}))
+	if _, err := configReader.Load(&loaded); err != nil {
+		return err
+	}
+	// maps are not populated on load; compare the Struct fields only
+	loaded.Queues = configObject.Queues
+	if !reflect.DeepEqual(loaded, configObject) {
+		return fmt.Errorf("expected [%v]; got [%v]", configObject, loaded)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^an in-memory worker pool config$`, anInMemoryWorkerPoolConfig)
+	s.Step(`^I save the config to "([^"]*)"$`, saveTheConfigTo)
+	s.Step(`^I save the config with dotted keys to "([^"]*)"$`, saveTheConfigWithDottedKeysTo)
+	s.Step(`^the file "([^"]*)" equals to the file "([^"]*)"$`, theFileEqualsToTheFile)
+	s.Step(`^loading "([^"]*)" yields the same config$`, loadingYieldsTheSameConfig)
+}
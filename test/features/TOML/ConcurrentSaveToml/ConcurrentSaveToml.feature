@@ -0,0 +1,88 @@
+Feature: TOML optimistic concurrency and three-way merge
+  With DetectConflicts set, Save fails with ErrConfigModified if the config
+  file was modified (content hash) since the last Load / Save instead of
+  clobbering the edits of others. Merge combines the loaded base, the file
+  on disk and the in-memory values (three-way) and reports the conflicts
+  per key; MergeTrees does the same at the document level.
+
+  Assumptions for the feature test:
+  - the config file is copied into a temp folder
+  - the remote edits are made through another TOMLConfigImpl (SetKey)
+  - the ServerConfig Struct is used (DemoTOMLConfig for the datetime values)
+
+  Major use cases:
+  - detect the concurrent modifications on Save
+  - merge non conflicting edits and save them
+  - report the conflicting keys
+  - save a copy to another file
+  - merge the datetime values
+  - merge requires a pointer to the config Struct
+  - merge 3 toml documents
+
+  Scenario: 1) Detect concurrent modifications
+    Given the config file "concurrentSaveToml.toml" is loaded with conflict detection
+    When the port is changed to 8443
+    And another operator sets the key "limits.timeout" to 20.0
+    Then saving the config fails as modified
+    And the config file has the port 8080 and the timeout 20
+
+  Scenario: 2) Save without conflict detection
+    Given the config file "concurrentSaveToml.toml" is loaded without conflict detection
+    When the port is changed to 8443
+    And another operator sets the key "limits.timeout" to 20.0
+    Then the config is saved
+    And the config file has the port 8443 and the timeout 12.5
+
+  Scenario: 3) Merge non conflicting edits
+    Given the config file "concurrentSaveToml.toml" is loaded with conflict detection
+    When the port is changed to 8443
+    And another operator sets the key "limits.timeout" to 20.0
+    Then the config is merged without conflicts
+    And the config has the port 8443 and the timeout 20
+    And the config is saved
+    And the config file has the port 8443 and the timeout 20
+
+  Scenario: 4) Report the conflicts
+    Given the config file "concurrentSaveToml.toml" is loaded with conflict detection
+    When the port is changed to 8443
+    And another operator sets the key "port" to 9443
+    Then merging the config reports the conflicts "port: 8080 -> 8443 (local), 9443 (remote)"
+    And the config has the port 8443 and the timeout 12.5
+    And saving the config fails as modified
+
+  Scenario: 5) Save moves the base forward
+    Given the config file "concurrentSaveToml.toml" is loaded with conflict detection
+    When the port is changed to 8443
+    Then the config is saved
+    When the port is changed to 8444
+    Then the config is saved
+    And the config file has the port 8444 and the timeout 12.5
+
+  Scenario: 6) Save a copy to another file
+    Given the config file "concurrentSaveToml.toml" is loaded with conflict detection
+    When the port is changed to 8443
+    Then the config is saved as a copy "backup.toml"
+    When another operator sets the key "limits.timeout" to 20.0
+    Then saving the config fails as modified
+    And the config is merged without conflicts
+    And the config is saved
+    And the config file has the port 8443 and the timeout 20
+
+  Scenario: 7) Merge the datetime values
+    Given the author config file "mergeTimeToml.toml" is loaded with conflict detection
+    When the working hours are changed to 6
+    And another operator sets the date "author.birthday" to "1991-01-01"
+    Then the config is merged without conflicts
+    And the config has the working hours 6 and the birthday "1991-01-01"
+    And the config is saved
+    And the config file has the working hours 6 and the birthday "1991-01-01"
+
+  Scenario: 8) Merge 3 toml documents
+    When the documents "mergeTomlBase.toml", "mergeTomlLocal.toml" and "mergeTomlRemote.toml" are merged
+    Then there are no merge conflicts
+    And the merged keys are "name = order-service; port = 8443; tags = [orders,payments]; limits.timeout = 20; hostname = orders.local"
+
+  Scenario: 9) Merge requires a pointer
+    Given the config file "concurrentSaveToml.toml" is loaded with conflict detection
+    When another operator sets the key "limits.timeout" to 20.0
+    Then merging the config held by value fails
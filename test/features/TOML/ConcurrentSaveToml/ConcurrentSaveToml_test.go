@@ -0,0 +1,280 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the optimistic concurrency of Save and the three-way merge
+package ConcurrentSaveToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.ServerConfig
+var authorObject TOML2.DemoTOMLConfig
+// the Struct pointer loaded (configObject or authorObject)
+var configPtr interface{}
+var configFile string
+var merged *TOML.Tree
+var conflicts []TOML.MergeConflict
+
+func loadConfigFile(fixture string, detectConflicts bool, ptrConfigObject interface{}) error {
+	dir, err := os.MkdirTemp("", "concurrentSaveToml")
+	if err != nil {
+		return err
+	}
+	configFile = filepath.Join(dir, "config.toml")
+	bBytes, err := common.LoadFile(fixture)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(configFile, bBytes, 0644); err != nil {
+		return err
+	}
+	configReader = TOML.NewTOMLConfigImpl(configFile, reflect.TypeOf(ptrConfigObject).Elem())
+	configReader.DetectConflicts = detectConflicts
+	configPtr = ptrConfigObject
+	_, err = configReader.Load(configPtr)
+	return err
+}
+
+func theConfigFileIsLoadedWithConflictDetection(fixture string) error {
+	configObject = TOML2.ServerConfig{}
+	return loadConfigFile(fixture, true, &configObject)
+}
+
+func theConfigFileIsLoadedWithoutConflictDetection(fixture string) error {
+	configObject = TOML2.ServerConfig{}
+	return loadConfigFile(fixture, false, &configObject)
+}
+
+func theAuthorConfigFileIsLoadedWithConflictDetection(fixture string) error {
+	authorObject = TOML2.DemoTOMLConfig{}
+	return loadConfigFile(fixture, true, &authorObject)
+}
+
+func thePortIsChangedTo(port int) error {
+	configObject.Port = port
+	return nil
+}
+
+func theWorkingHoursAreChangedTo(hours int) error {
+	authorObject.WorkingHoursDay = hours
+	return nil
+}
+
+func anotherOperatorSetsTheKeyTo(key, value string) error {
+	other := TOML.NewTOMLConfigImpl(configFile, reflect.TypeOf(configPtr).Elem())
+	return other.SetKey(key, parseValue(value))
+}
+
+func anotherOperatorSetsTheDateTo(key, value string) error {
+	date, err := parseDate(value)
+	if err != nil {
+		return err
+	}
+	other := TOML.NewTOMLConfigImpl(configFile, reflect.TypeOf(configPtr).Elem())
+	return other.SetKey(key, date)
+}
+
+// the value as a time.Time (a short date e.g. 1991-01-01)
+func parseDate(value string) (time.Time, error) {
+	return time.Parse(common.TimeShortDate, value)
+}
+
+// the value as a float64 (with a ".") or an int
+func parseValue(value string) interface{} {
+	if strings.Contains(value, ".") {
+		var fValue float64
+		fmt.Sscanf(value, "%g", &fValue)
+		return fValue
+	}
+	var iValue int
+	fmt.Sscanf(value, "%d", &iValue)
+	return iValue
+}
+
+func saveConfig() error {
+	return configReader.Save(configFile, configPtr)
+}
+
+func savingTheConfigFailsAsModified() error {
+	err := saveConfig()
+	if !errors.Is(err, TOML.ErrConfigModified) {
+		return fmt.Errorf("expected an ErrConfigModified; got [%v]", err)
+	}
+	return nil
+}
+
+func theConfigIsSaved() error {
+	return saveConfig()
+}
+
+func checkPortAndTimeout(config TOML2.ServerConfig, port int, timeout float64) error {
+	if config.Port != port || config.Limits.Timeout != timeout {
+		return fmt.Errorf("expected the port %v and the timeout %v; got %v and %v", port, timeout, config.Port, config.Limits.Timeout)
+	}
+	return nil
+}
+
+func theConfigIsSavedAsACopy(filename string) error {
+	return configReader.Save(filepath.Join(filepath.Dir(configFile), filename), configPtr)
+}
+
+func theConfigFileHasThePortAndTheTimeout(port int, timeout float64) error {
+	fileObject := TOML2.ServerConfig{}
+	reader := TOML.NewTOMLConfigImpl(configFile, reflect.TypeOf(fileObject))
+	if _, err := reader.Load(&fileObject); err != nil {
+		return err
+	}
+	return checkPortAndTimeout(fileObject, port, timeout)
+}
+
+func theConfigHasThePortAndTheTimeout(port int, timeout float64) error {
+	return checkPortAndTimeout(configObject, port, timeout)
+}
+
+func checkWorkingHoursAndBirthday(config TOML2.DemoTOMLConfig, hours int, birthday string) error {
+	date, err := parseDate(birthday)
+	if err != nil {
+		return err
+	}
+	if config.WorkingHoursDay != hours || !config.Author.Birthday.Equal(date) {
+		return fmt.Errorf("expected the working hours %v and the birthday %v; got %v and %v", hours, birthday, config.WorkingHoursDay, config.Author.Birthday)
+	}
+	return nil
+}
+
+func theConfigFileHasTheWorkingHoursAndTheBirthday(hours int, birthday string) error {
+	fileObject := TOML2.DemoTOMLConfig{}
+	reader := TOML.NewTOMLConfigImpl(configFile, reflect.TypeOf(fileObject))
+	if _, err := reader.Load(&fileObject); err != nil {
+		return err
+	}
+	return checkWorkingHoursAndBirthday(fileObject, hours, birthday)
+}
+
+func theConfigHasTheWorkingHoursAndTheBirthday(hours int, birthday string) error {
+	return checkWorkingHoursAndBirthday(authorObject, hours, birthday)
+}
+
+func theConfigIsMergedWithoutConflicts() error {
+	conflicts, err := configReader.Merge(configPtr)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("expected no conflicts; got %v", conflicts)
+	}
+	return nil
+}
+
+func mergingTheConfigHeldByValueFails() error {
+	if _, err := configReader.Merge(reflect.ValueOf(configPtr).Elem().Interface()); err == nil {
+		return fmt.Errorf("expected an error on merging a config held by value")
+	}
+	return nil
+}
+
+func mergingTheConfigReportsTheConflicts(expected string) error {
+	conflicts, err := configReader.Merge(configPtr)
+	var conflictError *TOML.MergeConflictError
+	if !errors.As(err, &conflictError) || len(conflictError.Conflicts) != len(conflicts) {
+		return fmt.Errorf("expected a MergeConflictError; got [%v]", err)
+	}
+	sConflicts := []string{}
+	for _, conflict := range conflicts {
+		sConflicts = append(sConflicts, conflict.String())
+	}
+	if actual := strings.Join(sConflicts, "; "); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected the conflicts [%v]; got [%v]", expected, actual)
+	}
+	return nil
+}
+
+func theDocumentsAndAreMerged(basePath, localPath, remotePath string) error {
+	trees := []*TOML.Tree{}
+	for _, path := range []string{ basePath, localPath, remotePath } {
+		tree, err := TOML.ParseFile(path)
+		if err != nil {
+			return err
+		}
+		trees = append(trees, tree)
+	}
+	var err error
+	merged, conflicts, err = TOML.MergeTrees(trees[0], trees[1], trees[2])
+	return err
+}
+
+func thereAreNoMergeConflicts() error {
+	if len(conflicts) > 0 {
+		return fmt.Errorf("expected no conflicts; got %v", conflicts)
+	}
+	return nil
+}
+
+func theMergedKeysAre(expected string) error {
+	sKeys := []string{}
+	for _, key := range merged.Keys() {
+		value := merged.Get(key)
+		if elements, ok := value.([]interface{}); ok {
+			sElements := []string{}
+			for _, element := range elements {
+				sElements = append(sElements, fmt.Sprintf("%v", element))
+			}
+			value = "[" + strings.Join(sElements, ",") + "]"
+		}
+		sKeys = append(sKeys, fmt.Sprintf("%v = %v", key, value))
+	}
+	if actual := strings.Join(sKeys, "; "); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected the merged keys [%v]; got [%v]", expected, actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^the config file "([^"]*)" is loaded with conflict detection$`, theConfigFileIsLoadedWithConflictDetection)
+	s.Step(`^the config file "([^"]*)" is loaded without conflict detection$`, theConfigFileIsLoadedWithoutConflictDetection)
+	s.Step(`^the author config file "([^"]*)" is loaded with conflict detection$`, theAuthorConfigFileIsLoadedWithConflictDetection)
+	s.Step(`^the port is changed to (\d+)$`, thePortIsChangedTo)
+	s.Step(`^the working hours are changed to (\d+)$`, theWorkingHoursAreChangedTo)
+	s.Step(`^another operator sets the key "([^"]*)" to ([\d.]+)$`, anotherOperatorSetsTheKeyTo)
+	s.Step(`^another operator sets the date "([^"]*)" to "([^"]*)"$`, anotherOperatorSetsTheDateTo)
+	s.Step(`^saving the config fails as modified$`, savingTheConfigFailsAsModified)
+	s.Step(`^the config is saved$`, theConfigIsSaved)
+	s.Step(`^the config is saved as a copy "([^"]*)"$`, theConfigIsSavedAsACopy)
+	s.Step(`^the config file has the port (\d+) and the timeout ([\d.]+)$`, theConfigFileHasThePortAndTheTimeout)
+	s.Step(`^the config file has the working hours (\d+) and the birthday "([^"]*)"$`, theConfigFileHasTheWorkingHoursAndTheBirthday)
+	s.Step(`^the config has the working hours (\d+) and the birthday "([^"]*)"$`, theConfigHasTheWorkingHoursAndTheBirthday)
+	s.Step(`^the config has the port (\d+) and the timeout ([\d.]+)$`, theConfigHasThePortAndTheTimeout)
+	s.Step(`^the config is merged without conflicts$`, theConfigIsMergedWithoutConflicts)
+	s.Step(`^merging the config held by value fails$`, mergingTheConfigHeldByValueFails)
+	s.Step(`^merging the config reports the conflicts "([^"]*)"$`, mergingTheConfigReportsTheConflicts)
+	s.Step(`^the documents "([^"]*)", "([^"]*)" and "([^"]*)" are merged$`, theDocumentsAndAreMerged)
+	s.Step(`^there are no merge conflicts$`, thereAreNoMergeConflicts)
+	s.Step(`^the merged keys are "([^"]*)"$`, theMergedKeysAre)
+}
@@ -0,0 +1,26 @@
+Feature: TOML backend through the format neutral IConfig interface
+  interfaces.IConfig declares Load (into a Struct pointer), Save (from a
+  Struct value or pointer) plus the streaming Decode / Encode; the TOML
+  backend (TOMLConfigImpl) implements it, hence callers could program
+  against the interface and switch backends later.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - load and save through the interface
+  - decode and encode streams through the interface
+
+  Scenario: 1) Load and Save through IConfig
+    Given an IConfig for the TOML file named "configInterfaceToml.toml"
+    When I load the config through the interface
+    And save the config through the interface to "configInterfaceToml_test.toml"
+    Then the name is "order-service"
+    And the file "configInterfaceToml_test.toml" equals to the file "configInterfaceTomlExpected.toml"
+
+  Scenario: 2) Decode and Encode through IConfig
+    Given an IConfig for the TOML file named "configInterfaceToml.toml"
+    When I decode the file "configInterfaceToml.toml" through the interface
+    Then the name is "order-service"
+    And encoding the config through the interface equals to the file "configInterfaceTomlExpected.toml"
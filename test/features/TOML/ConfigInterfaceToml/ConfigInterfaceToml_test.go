@@ -0,0 +1,106 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the TOML backend through the IConfig interface
+package ConfigInterfaceToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/interfaces"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var config interfaces.IConfig
+var configObject TOML2.ServerConfig
+
+func anIConfigForTheTomlFileNamed(tomlFile string) error {
+	configReader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.ServerConfig{}))
+	config = &configReader
+	configObject = TOML2.ServerConfig{}
+	return nil
+}
+
+func loadTheConfigThroughTheInterface() error {
+	_, err := config.Load(&configObject)
+	return err
+}
+
+func saveTheConfigThroughTheInterfaceTo(tomlFile string) error {
+	return config.Save(tomlFile, configObject)
+}
+
+func decodeTheFileThroughTheInterface(tomlFile string) error {
+	file, err := os.Open(tomlFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return config.Decode(file, &configObject)
+}
+
+func theNameIs(name string) error {
+	if strings.Compare(configObject.Name, name) != 0 {
+		return fmt.Errorf("expected name [%v]; got [%v]", name, configObject.Name)
+	}
+	return nil
+}
+
+func encodingTheConfigThroughTheInterfaceEqualsToTheFile(expectedFilename string) error {
+	var bBuffer bytes.Buffer
+	if err := config.Encode(&bBuffer, configObject); err != nil {
+		return err
+	}
+	return contentEqualsToTheFile(bBuffer.Bytes(), expectedFilename)
+}
+
+func theFileEqualsToTheFile(filename, expectedFilename string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return contentEqualsToTheFile(bContent, expectedFilename)
+}
+
+func contentEqualsToTheFile(bContent []byte, expectedFilename string) error {
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the contents differ from [%v]; got =>\n%v", expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^an IConfig for the TOML file named "([^"]*)"$`, anIConfigForTheTomlFileNamed)
+	s.Step(`^I load the config through the interface$`, loadTheConfigThroughTheInterface)
+	s.Step(`^save the config through the interface to "([^"]*)"$`, saveTheConfigThroughTheInterfaceTo)
+	s.Step(`^I decode the file "([^"]*)" through the interface$`, decodeTheFileThroughTheInterface)
+	s.Step(`^the name is "([^"]*)"$`, theNameIs)
+	s.Step(`^encoding the config through the interface equals to the file "([^"]*)"$`, encodingTheConfigThroughTheInterfaceEqualsToTheFile)
+	s.Step(`^the file "([^"]*)" equals to the file "([^"]*)"$`, theFileEqualsToTheFile)
+}
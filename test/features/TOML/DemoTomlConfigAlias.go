@@ -0,0 +1,33 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing Struct for key aliases and deprecated keys.
+package TOML
+
+/*
+ *	a struct to describe the "work" schedule
+ */
+
+// Struct wrapping up a "work" schedule; some keys were renamed and the old
+// keys are kept as aliases
+type WorkConfig struct {
+	HoursPerDay int `toml:"work.hoursPerDay" alias:"workingHoursDay" deprecated:"use work.hoursPerDay"`
+	// no migration hint => "use work.daysPerWeek"
+	DaysPerWeek int `toml:"work.daysPerWeek" alias:"workingDays,daysPerWeek"`
+	// the key itself is deprecated
+	Overtime bool `toml:"work.overtime" deprecated:"overtime is no longer configurable"`
+	Office string `toml:"work.office"`
+}
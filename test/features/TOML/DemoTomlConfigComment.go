@@ -0,0 +1,46 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing Struct for the comment:"..." and doc:"..." Tags written on save.
+package TOML
+
+/*
+ *	a struct to describe a "worker pool" service
+ */
+
+// Struct wrapping up a worker pool service with commented fields
+type WorkerPoolConfig struct {
+	Name string `toml:"name" comment:"Service name shown in the dashboards"`
+	Port int `toml:"port" comment:"Listening port\nrestart required after a change"`
+	Debug bool `toml:"debug"`
+
+	Pool WorkerPool `toml:"pool" additional:"parent" doc:"Worker pool settings"`
+	Queues map[string]int `toml:"queues" doc:"Queue name => max pending jobs"`
+}
+
+// Struct wrapping up the worker pool settings
+type WorkerPool struct {
+	MaxWorkers int `toml:"pool.maxWorkers" comment:"Max concurrent workers (1-64)"`
+	IdleTimeout float64 `toml:"pool.idleTimeout" comment:"Seconds before an idle worker exits"`
+	Retry WorkerRetry `toml:"pool.retry" additional:"parent" doc:"Retry policy of the failed jobs"`
+}
+
+// Struct wrapping up the retry policy of the worker pool
+type WorkerRetry struct {
+	Attempts int `toml:"pool.retry.attempts" comment:"0 => no retry"`
+	Backoff float64 `toml:"pool.retry.backoff"`
+}
@@ -0,0 +1,36 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing Struct for the float formatting on save / reload.
+package TOML
+
+/*
+ *	a struct to describe a "sensor" reading
+ */
+
+// Struct wrapping up float32 / float64 values (including inf and nan)
+type SensorReading struct {
+	Name string `toml:"name"`
+	Height float32 `toml:"height"`
+	Weight float64 `toml:"weight"`
+	Ceiling float64 `toml:"ceiling"`
+	Floor float64 `toml:"floor"`
+	Drift float64 `toml:"drift"`
+	Epsilon float64 `toml:"epsilon"`
+	Samples32 []float32 `toml:"samples32"`
+	Samples64 []float64 `toml:"samples64"`
+}
@@ -0,0 +1,30 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing Struct for saving maps (nested ones included) as tables.
+package TOML
+
+/*
+ *	a struct to describe a server "inventory"
+ */
+
+// Struct wrapping up a server inventory with free form maps
+type ServerInventory struct {
+	Name string `toml:"name"`
+	Labels map[string]string `toml:"labels"`
+	// region name => region settings (map[string]interface{})
+	Regions map[string]interface{} `toml:"regions"`
+}
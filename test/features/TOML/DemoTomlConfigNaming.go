@@ -0,0 +1,49 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing Struct for the key naming strategies (fields without toml Tags).
+package TOML
+
+/*
+ *	a struct to describe a "cache" service
+ */
+
+// Struct wrapping up a "cache" configuration; most fields carry no toml Tag
+// and are keyed through the naming strategy
+type CacheConfig struct {
+	ServiceName string
+	MaxEntries int
+	HTTPPort int
+	EvictionEnabled bool
+	// an explicit toml Tag always wins over the naming strategy
+	Region string `toml:"cache.region"`
+
+	// unexported fields are never keyed by the naming strategy
+	internalState string
+
+	// struct to describe the backend storage; its key is derived as well
+	Backend CacheBackend `additional:"parent"`
+}
+
+/*
+ *	a struct to describe the "backend" of a cache service
+ */
+
+// Struct wrapping up the backend storage of a cache service
+type CacheBackend struct {
+	HostName string
+	TimeoutSeconds float64
+}
@@ -0,0 +1,66 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing Struct for the toml Tag options (omitempty, "-" and inline).
+package TOML
+
+/*
+ *	a struct to describe a "database" connection
+ */
+
+// Struct wrapping up a "database" configuration with toml Tag options
+type DatabaseConfig struct {
+	Host string `toml:"host"`
+	Port int `toml:"port"`
+	// omitted on save when empty
+	Password string `toml:"password,omitempty"`
+	Replicas []string `toml:"replicas,omitempty"`
+	RetryCount int `toml:"retryCount,omitempty"`
+
+	// runtime only state; never loaded nor saved
+	Connected bool `toml:"-"`
+
+	// the pool settings are declared at the same level as the database settings
+	Pool DatabasePool `toml:",inline"`
+}
+
+/*
+ *	a struct to describe the connection "pool" of a database
+ */
+
+// Struct wrapping up the connection pool settings (inlined into DatabaseConfig)
+type DatabasePool struct {
+	MaxOpen int `toml:"maxOpen"`
+	MaxIdle int `toml:"maxIdle,omitempty"`
+}
+
+/*
+ *	a struct to describe a "replica" of a database
+ */
+
+// Struct wrapping up a "replica" configuration; the primary settings are
+// declared through a parent field without a toml Tag (the child Tags carry
+// the full keys)
+type ReplicaConfig struct {
+	Name string `toml:"name"`
+	Primary ReplicaPrimary `additional:"parent"`
+}
+
+// Struct wrapping up the primary database of a replica
+type ReplicaPrimary struct {
+	Host string `toml:"primary.host"`
+	Port int `toml:"primary.port"`
+}
@@ -0,0 +1,194 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing Struct for field level validations and lifecycle hooks.
+package TOML
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+/*
+ *	a struct to describe a "server"
+ */
+
+// Struct wrapping up a "server" configuration with validation rules
+type ServerConfig struct {
+	Name string `toml:"name" validate:"len>0,regex=^[a-z-]+$"`
+	Port int `toml:"port" validate:"min=1,max=65535"`
+	Role string `toml:"role" validate:"oneof=admin user"`
+	Tags []string `toml:"tags" validate:"len>0,max=3"`
+	// normalised (lower-cased) by the AfterLoad hook
+	Hostname string `toml:"hostname"`
+
+	// struct to describe the worker limits
+	Limits ServerLimits `toml:"limits" additional:"parent"`
+
+	// struct to describe the tls setup
+	TLS ServerTLS `toml:"tls" additional:"parent"`
+}
+
+/*
+ *	a struct to describe the "limits" of a server
+ */
+
+// Struct wrapping up the worker limits of a server
+type ServerLimits struct {
+	MinWorkers int `toml:"limits.minWorkers" validate:"min=1,lte_field=limits.maxWorkers"`
+	MaxWorkers int `toml:"limits.maxWorkers" validate:"min=1,max=64"`
+	Timeout float64 `toml:"limits.timeout" validate:"max=30.5"`
+}
+
+/*
+ *	a struct to describe the "tls" setup of a server
+ */
+
+// Struct wrapping up the tls setup of a server
+type ServerTLS struct {
+	Enabled bool `toml:"tls.enabled"`
+	// relative cert / key files are resolved against the BaseDir by the AfterLoad hook
+	BaseDir string `toml:"tls.baseDir"`
+	CertFile string `toml:"tls.certFile" validate:"required_if=tls.enabled true"`
+	KeyFile string `toml:"tls.keyFile" validate:"required_if=tls.enabled true"`
+}
+
+
+/* -------------------- */
+/*	validation hooks	*/
+/* -------------------- */
+
+// Struct level validation hook (check IConfig.go)
+func (o *ServerTLS) Validate() error {
+	if o.Enabled && len(o.CertFile) > 0 && o.CertFile == o.KeyFile {
+		return fmt.Errorf("the cert file and key file should be different [%v]", o.CertFile)
+	}
+	return nil
+}
+
+// Struct level validation hook (check IConfig.go)
+func (o *ServerConfig) Validate() error {
+	if o.Role == "admin" && !o.TLS.Enabled {
+		return fmt.Errorf("admin servers must enable tls")
+	}
+	return nil
+}
+
+
+/* -------------------- */
+/*	lifecycle hooks		*/
+/* -------------------- */
+
+// the lifeCycle Hook method implementation (check IConfig.go)
+func (o *ServerConfig) SetStructsReferences(structRefMap *map[string]interface{}) (err error) {
+	structRefMapVal := *structRefMap
+	if len(structRefMapVal)==0 {
+		return nil
+	}
+	for key, structRef := range structRefMapVal {
+		switch key {
+		case "TOML.ServerLimits":
+			o.Limits = reflect.Indirect(reflect.ValueOf(structRef)).Interface().(ServerLimits)
+		case "TOML.ServerTLS":
+			o.TLS = reflect.Indirect(reflect.ValueOf(structRef)).Interface().(ServerTLS)
+		default:
+			return fmt.Errorf("unknown struct type! [%v]", key)
+		}
+	}	// end -- for (structRef)
+	return nil
+}
+
+
+/* -------------------- */
+/*	lifecycle hooks		*/
+/* -------------------- */
+
+// records the lifecycle hooks invoked (in order); handy for verifying the
+// order of invocation
+var HookTrace []string
+
+func traceHook(hook string, object interface{}) {
+	HookTrace = append(HookTrace, fmt.Sprintf("%v:%v", hook, reflect.TypeOf(object).Elem().Name()))
+}
+
+// BeforeLoad lifecycle hook (check IConfig.go)
+func (o *ServerConfig) BeforeLoad() error {
+	traceHook("BeforeLoad", o)
+	return nil
+}
+
+// AfterLoad lifecycle hook (check IConfig.go); normalise the names
+func (o *ServerConfig) AfterLoad() error {
+	traceHook("AfterLoad", o)
+	o.Name = strings.ToLower(o.Name)
+	o.Hostname = strings.ToLower(o.Hostname)
+	return nil
+}
+
+// BeforeSave lifecycle hook (check IConfig.go)
+func (o *ServerConfig) BeforeSave() error {
+	traceHook("BeforeSave", o)
+	return nil
+}
+
+// AfterSave lifecycle hook (check IConfig.go)
+func (o *ServerConfig) AfterSave() error {
+	traceHook("AfterSave", o)
+	return nil
+}
+
+// BeforeLoad lifecycle hook (check IConfig.go); default the timeout (overridden by the loaded key)
+func (o *ServerLimits) BeforeLoad() error {
+	traceHook("BeforeLoad", o)
+	if o.Timeout == 0 {
+		o.Timeout = 10
+	}
+	return nil
+}
+
+// AfterLoad lifecycle hook (check IConfig.go); default the minimum workers if missing
+func (o *ServerLimits) AfterLoad() error {
+	traceHook("AfterLoad", o)
+	if o.MinWorkers == 0 {
+		o.MinWorkers = 1
+	}
+	return nil
+}
+
+// BeforeSave lifecycle hook (check IConfig.go); derive the minimum workers if missing
+func (o *ServerLimits) BeforeSave() error {
+	traceHook("BeforeSave", o)
+	if o.MinWorkers == 0 {
+		o.MinWorkers = 1
+	}
+	return nil
+}
+
+// AfterLoad lifecycle hook (check IConfig.go); resolve the relative paths
+func (o *ServerTLS) AfterLoad() error {
+	traceHook("AfterLoad", o)
+	if len(o.BaseDir) > 0 {
+		if len(o.CertFile) > 0 && !filepath.IsAbs(o.CertFile) {
+			o.CertFile = filepath.Join(o.BaseDir, o.CertFile)
+		}
+		if len(o.KeyFile) > 0 && !filepath.IsAbs(o.KeyFile) {
+			o.KeyFile = filepath.Join(o.BaseDir, o.KeyFile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+Feature: TOML structural diff
+  TOML.Diff compares 2 config Structs and TOML.DiffFiles compares 2 toml
+  files at the document level; both return typed change records (added,
+  removed or modified with the key and the values) which could be rendered
+  as a summary or a unified text diff.
+
+  Assumptions for the feature test:
+  - the config Structs are populated in memory
+  - the toml files are compared without a Struct
+
+  Major use cases:
+  - summarise the changes e.g. "author.age: 25 -> 26", "hobbies: +guitar"
+  - report the added, removed and modified keys of 2 files
+  - render the changes as a unified text diff
+
+  Scenario: 1) Diff 2 config Structs
+    Given an in-memory demo config
+    And a copy with the author age 26 and the extra hobby "guitar"
+    When the configs are compared
+    Then the changes are "hobbies: +guitar; author.age: 25 -> 26"
+    And the change types are "modified, modified"
+    And the old value of "author.age" is 25 and the new value is 26
+
+  Scenario: 2) Identical configs
+    Given an in-memory demo config
+    When the config is compared with itself
+    Then the changes are ""
+
+  Scenario: 3) Diff 2 files
+    When the files "diffTomlA.toml" and "diffTomlB.toml" are compared
+    Then the changes are "currency added: KRW; client.address.city: Seoul -> Busan; broker.licences: +it-approved, -cpa-approved; broker.id removed"
+    And the change types are "added, modified, modified, removed"
+    And the unified diff matches "diffTomlUnifiedExpected.txt"
+
+  Scenario: 4) Missing files
+    Then comparing the files "diffTomlA.toml" and "diffTomlMissing.toml" fails
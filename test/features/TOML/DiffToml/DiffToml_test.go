@@ -0,0 +1,131 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the structural diff of configs and files
+package DiffToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configA TOML2.DemoTOMLConfig
+var configB TOML2.DemoTOMLConfig
+var changes []TOML.Change
+var fileA, fileB string
+
+func anInMemoryDemoConfig() error {
+	configA = TOML2.DemoTOMLConfig{}
+	configA.Role = "admin"
+	configA.Author.FirstName = "Jason"
+	configA.Author.Age = 25
+	configA.Hobbies = []string{ "badminton", "reading" }
+	return nil
+}
+
+func aCopyWithTheAuthorAgeAndTheExtraHobby(age int, hobby string) error {
+	configB = configA
+	configB.Author.Age = age
+	configB.Hobbies = append(append([]string{}, configA.Hobbies...), hobby)
+	return nil
+}
+
+func theConfigsAreCompared() error {
+	changes = TOML.Diff(configA, &configB)
+	return nil
+}
+
+func theConfigIsComparedWithItself() error {
+	changes = TOML.Diff(&configA, &configA)
+	return nil
+}
+
+func theFilesAreCompared(pathA, pathB string) (err error) {
+	fileA, fileB = pathA, pathB
+	changes, err = TOML.DiffFiles(pathA, pathB)
+	return err
+}
+
+func comparingTheFilesFails(pathA, pathB string) error {
+	if _, err := TOML.DiffFiles(pathA, pathB); err == nil {
+		return fmt.Errorf("expected an error on comparing [%v] and [%v]", pathA, pathB)
+	}
+	return nil
+}
+
+func theChangesAre(expected string) error {
+	summaries := []string{}
+	for _, change := range changes {
+		summaries = append(summaries, change.String())
+	}
+	if actual := strings.Join(summaries, "; "); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected the changes [%v]; got [%v]", expected, actual)
+	}
+	return nil
+}
+
+func theChangeTypesAre(expected string) error {
+	types := []string{}
+	for _, change := range changes {
+		types = append(types, change.Type.String())
+	}
+	if actual := strings.Join(types, ", "); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected the change types [%v]; got [%v]", expected, actual)
+	}
+	return nil
+}
+
+func theOldValueOfIsAndTheNewValueIs(key string, oldValue, newValue int) error {
+	for _, change := range changes {
+		if change.Key == key {
+			if change.OldValue != oldValue || change.NewValue != newValue {
+				return fmt.Errorf("expected [%v -> %v] for [%v]; got [%#v -> %#v]", oldValue, newValue, key, change.OldValue, change.NewValue)
+			}
+			return nil
+		}
+	}	// end -- for (changes)
+	return fmt.Errorf("no change of the key [%v]", key)
+}
+
+func theUnifiedDiffMatches(expectedFile string) error {
+	bBytes, err := common.LoadFile(expectedFile)
+	if err != nil {
+		return err
+	}
+	if actual := TOML.FormatUnifiedDiff(changes, fileA, fileB); strings.Compare(actual, string(bBytes)) != 0 {
+		return fmt.Errorf("expected the unified diff\n%v\nBUT got\n%v", string(bBytes), actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^an in-memory demo config$`, anInMemoryDemoConfig)
+	s.Step(`^a copy with the author age (\d+) and the extra hobby "([^"]*)"$`, aCopyWithTheAuthorAgeAndTheExtraHobby)
+	s.Step(`^the configs are compared$`, theConfigsAreCompared)
+	s.Step(`^the config is compared with itself$`, theConfigIsComparedWithItself)
+	s.Step(`^the files "([^"]*)" and "([^"]*)" are compared$`, theFilesAreCompared)
+	s.Step(`^comparing the files "([^"]*)" and "([^"]*)" fails$`, comparingTheFilesFails)
+	s.Step(`^the changes are "([^"]*)"$`, theChangesAre)
+	s.Step(`^the change types are "([^"]*)"$`, theChangeTypesAre)
+	s.Step(`^the old value of "([^"]*)" is (\d+) and the new value is (\d+)$`, theOldValueOfIsAndTheNewValueIs)
+	s.Step(`^the unified diff matches "([^"]*)"$`, theUnifiedDiffMatches)
+}
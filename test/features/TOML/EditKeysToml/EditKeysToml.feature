@@ -0,0 +1,34 @@
+Feature: TOML file level key operations
+  TOMLConfigImpl.SetKey, DeleteKey and RenameKey edit the config file in
+  place without a Struct; comments, blank lines and key order are kept.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+  - the file is copied before the test as it is written back
+
+  Major use cases:
+  - set an existing key; add a new key to its [table]
+  - set a key under a missing table; the [table] is created
+  - delete and rename keys; unknown keys are reported as errors
+
+  Scenario: 1) Set existing and new keys
+    Given a copy of "editKeysToml.toml" named "editKeysTomlSet_test.toml"
+    When I set the key "role" to the string "user" in "editKeysTomlSet_test.toml"
+    And I set the key "author.age" to the integer "30" in "editKeysTomlSet_test.toml"
+    And I set the key "author.lastName" to the string "Wong" in "editKeysTomlSet_test.toml"
+    And I set the key "author.address.city" to the string "Hong Kong" in "editKeysTomlSet_test.toml"
+    Then the file "editKeysTomlSet_test.toml" equals to the file "editKeysTomlSetExpected.toml"
+
+  Scenario: 2) Delete and rename keys
+    Given a copy of "editKeysToml.toml" named "editKeysTomlRename_test.toml"
+    When I delete the key "workingHoursDay" in "editKeysTomlRename_test.toml"
+    And I rename the key "author.firstName" to "author.givenName" in "editKeysTomlRename_test.toml"
+    And I rename the key "role" to "author.role" in "editKeysTomlRename_test.toml"
+    Then the file "editKeysTomlRename_test.toml" equals to the file "editKeysTomlRenameExpected.toml"
+
+  Scenario: 3) Unknown or existing keys are errors
+    Given a copy of "editKeysToml.toml" named "editKeysTomlError_test.toml"
+    Then deleting the key "unknown" in "editKeysTomlError_test.toml" fails
+    And renaming the key "role" to "author.age" in "editKeysTomlError_test.toml" fails
+    And the file "editKeysTomlError_test.toml" equals to the file "editKeysToml.toml"
@@ -0,0 +1,106 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the file level key operations (set, delete and rename)
+package EditKeysToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/quoeamaster/CFactor/TOML"
+)
+
+func aCopyOfNamed(source, target string) error {
+	bContent, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(target, bContent, 0644)
+}
+
+func getConfigReader(tomlFile string) TOML.TOMLConfigImpl {
+	return TOML.NewTOMLConfigImpl(tomlFile, nil)
+}
+
+func setTheKeyToTheString(key, value, tomlFile string) error {
+	configReader := getConfigReader(tomlFile)
+	return configReader.SetKey(key, value)
+}
+
+func setTheKeyToTheInteger(key, value, tomlFile string) error {
+	iValue, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	configReader := getConfigReader(tomlFile)
+	return configReader.SetKey(key, iValue)
+}
+
+func deleteTheKey(key, tomlFile string) error {
+	configReader := getConfigReader(tomlFile)
+	return configReader.DeleteKey(key)
+}
+
+func renameTheKeyTo(oldKey, newKey, tomlFile string) error {
+	configReader := getConfigReader(tomlFile)
+	return configReader.RenameKey(oldKey, newKey)
+}
+
+func deletingTheKeyFails(key, tomlFile string) error {
+	if err := deleteTheKey(key, tomlFile); err == nil {
+		return fmt.Errorf("expected an error on deleting key [%v]", key)
+	}
+	return nil
+}
+
+func renamingTheKeyToFails(oldKey, newKey, tomlFile string) error {
+	if err := renameTheKeyTo(oldKey, newKey, tomlFile); err == nil {
+		return fmt.Errorf("expected an error on renaming key [%v] to [%v]", oldKey, newKey)
+	}
+	return nil
+}
+
+func theFileEqualsToTheFile(filename, expectedFilename string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the file [%v] differs from [%v]; got =>\n%v", filename, expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^a copy of "([^"]*)" named "([^"]*)"$`, aCopyOfNamed)
+	s.Step(`^I set the key "([^"]*)" to the string "([^"]*)" in "([^"]*)"$`, setTheKeyToTheString)
+	s.Step(`^I set the key "([^"]*)" to the integer "([^"]*)" in "([^"]*)"$`, setTheKeyToTheInteger)
+	s.Step(`^I delete the key "([^"]*)" in "([^"]*)"$`, deleteTheKey)
+	s.Step(`^I rename the key "([^"]*)" to "([^"]*)" in "([^"]*)"$`, renameTheKeyTo)
+	s.Step(`^deleting the key "([^"]*)" in "([^"]*)" fails$`, deletingTheKeyFails)
+	s.Step(`^renaming the key "([^"]*)" to "([^"]*)" in "([^"]*)" fails$`, renamingTheKeyToFails)
+	s.Step(`^the file "([^"]*)" equals to the file "([^"]*)"$`, theFileEqualsToTheFile)
+}
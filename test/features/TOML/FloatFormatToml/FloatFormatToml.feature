@@ -0,0 +1,24 @@
+Feature: TOML float formatting on save
+  Floats are written in the shortest form reading back to the same value
+  at the field's bit size (float32 / float64); a decimal point is always
+  kept (e.g. 990.0) and infinity / NaN are written as inf, -inf and nan.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - float32 values are not written as their float64 approximation
+  - integral floats keep the decimal point
+  - the saved values are the same after reload
+
+  Scenario: 1) Save and reload floats
+    Given an in-memory sensor reading
+    When I save the reading to "floatFormatToml_test.toml"
+    Then the file "floatFormatToml_test.toml" equals to the file "floatFormatTomlExpected.toml"
+    And reloading "floatFormatToml_test.toml" yields the same reading
+
+  Scenario: 2) Load toml float forms
+    Given there is a TOML in the current folder named "floatFormatTomlForms.toml"
+    When I load the reading from "floatFormatTomlForms.toml"
+    Then the weight is "1000.5", the ceiling is "+Inf" and the drift is "NaN"
@@ -0,0 +1,122 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the float formatting on save / reload
+package FloatFormatToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var reading TOML2.SensorReading
+
+func anInMemorySensorReading() error {
+	reading = TOML2.SensorReading{
+		Name: "sensor-a",
+		Height: 12.3,
+		Weight: 990,
+		Ceiling: math.Inf(1),
+		Floor: math.Inf(-1),
+		Drift: math.NaN(),
+		Epsilon: 1e-7,
+		Samples32: []float32{ 0.1, 175.3, 2 },
+		Samples64: []float64{ 0.1, 1e21, -3 },
+	}
+	return nil
+}
+
+func saveTheReadingTo(tomlFile string) error {
+	configReader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.SensorReading{}))
+	return configReader.Save(tomlFile, reading)
+}
+
+func theFileEqualsToTheFile(filename, expectedFilename string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the file [%v] differs from [%v]; got =>\n%v", filename, expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+func loadTheReading(tomlFile string) (TOML2.SensorReading, error) {
+	loaded := TOML2.SensorReading{}
+	configReader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.SensorReading{}))
+	_, err := configReader.Load(&loaded)
+	return loaded, err
+}
+
+func reloadingYieldsTheSameReading(tomlFile string) error {
+	loaded, err := loadTheReading(tomlFile)
+	if err != nil {
+		return err
+	}
+	// NaN never equals to itself
+	if !math.IsNaN(loaded.Drift) {
+		return fmt.Errorf("expected drift to be NaN; got [%v]", loaded.Drift)
+	}
+	loaded.Drift, reading.Drift = 0, 0
+	if !reflect.DeepEqual(loaded, reading) {
+		return fmt.Errorf("expected [%v]; got [%v]", reading, loaded)
+	}
+	return nil
+}
+
+func gotTomlFileName(tomlFile string) error {
+	_, err := ioutil.ReadFile(tomlFile)
+	return err
+}
+
+func loadTheReadingFrom(tomlFile string) error {
+	var err error
+	reading, err = loadTheReading(tomlFile)
+	return err
+}
+
+func theWeightTheCeilingAndTheDriftAre(weight, ceiling, drift string) error {
+	actual := fmt.Sprintf("%v,%v,%v", reading.Weight, reading.Ceiling, reading.Drift)
+	if expected := strings.Join([]string{ weight, ceiling, drift }, ","); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected [%v]; got [%v]", expected, actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^an in-memory sensor reading$`, anInMemorySensorReading)
+	s.Step(`^I save the reading to "([^"]*)"$`, saveTheReadingTo)
+	s.Step(`^the file "([^"]*)" equals to the file "([^"]*)"$`, theFileEqualsToTheFile)
+	s.Step(`^reloading "([^"]*)" yields the same reading$`, reloadingYieldsTheSameReading)
+	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, gotTomlFileName)
+	s.Step(`^I load the reading from "([^"]*)"$`, loadTheReadingFrom)
+	s.Step(`^the weight is "([^"]*)", the ceiling is "([^"]*)" and the drift is "([^"]*)"$`, theWeightTheCeilingAndTheDriftAre)
+}
@@ -0,0 +1,55 @@
+Feature: TOML hot reload on file change
+  TOML.Watcher reloads the Store when its config file is modified (inotify
+  on linux with a polling fallback); editor saves through a temp file plus
+  rename are handled and bursts of events are debounced. If the new file
+  fails to parse or validate, the previous config keeps being served and
+  the error is reported through OnError.
+
+  Assumptions for the feature test:
+  - the config file is copied into a temp folder and modified while watched
+  - the ServerConfig Struct (with validation rules) is used
+
+  Major use cases:
+  - in place writes, rename saves and re-created files are reloaded
+  - polling detects the changes as well
+  - a burst of writes results in a single reload
+  - invalid contents keep the last known good config
+  - nothing is reloaded once the watcher is stopped
+
+  Scenario: 1) Reload on in place writes and rename saves
+    Given a watched config file "hotReloadToml.toml"
+    Then the served config has the name "order-service"
+    When the config file is overwritten by "hotReloadTomlUpdated.toml"
+    Then the served config has the name "billing-service" after 1 reload
+    When the config file is saved through a rename by "hotReloadToml.toml"
+    Then the served config has the name "order-service" after 2 reloads
+
+  Scenario: 2) Reload a deleted and re-created file
+    Given a watched config file "hotReloadToml.toml"
+    When the config file is deleted
+    And the config file is overwritten by "hotReloadTomlUpdated.toml"
+    Then the served config has the name "billing-service" after 1 reload
+    And no reload error is reported
+
+  Scenario: 3) Keep the last known good config
+    Given a watched config file "hotReloadToml.toml"
+    When the config file is overwritten by "hotReloadTomlInvalid.toml"
+    Then a reload error is reported
+    And the served config has the name "order-service" after 0 reloads
+    When the config file is overwritten by "hotReloadTomlUpdated.toml"
+    Then the served config has the name "billing-service" after 1 reload
+
+  Scenario: 4) Polling
+    Given a polled config file "hotReloadToml.toml"
+    When the config file is overwritten by "hotReloadTomlUpdated.toml"
+    Then the served config has the name "billing-service" after 1 reload
+
+  Scenario: 5) Debounce a burst of writes
+    Given a watched config file "hotReloadToml.toml"
+    When the config file is written 5 times in a burst ending with "hotReloadTomlUpdated.toml"
+    Then the served config has the name "billing-service" after 1 reload
+    And no reload error is reported
+
+  Scenario: 6) Stop during a reload
+    Given a watched config file "hotReloadToml.toml"
+    Then stopping the watcher during the reload of "hotReloadTomlUpdated.toml" reloads nothing else for 10 times
@@ -0,0 +1,252 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the hot reload of the config file
+package HotReloadToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var watcher *TOML.Watcher[TOML2.ServerConfig]
+var configFile string
+var lock sync.Mutex
+var reloads int
+var reloadErrors []error
+// invoked (if set) within OnReload e.g. to keep the watcher busy
+var onReloadHook func()
+
+// the max. waiting time for a reload
+const reloadTimeout = 3 * time.Second
+
+func watchConfigFile(fixture string, polling bool) error {
+	if watcher != nil {
+		watcher.Stop()
+	}
+	dir, err := os.MkdirTemp("", "hotReloadToml")
+	if err != nil {
+		return err
+	}
+	configFile = filepath.Join(dir, "config.toml")
+	if err := copyFixture(fixture, configFile); err != nil {
+		return err
+	}
+	lock.Lock()
+	reloads = 0
+	reloadErrors = []error{}
+	onReloadHook = nil
+	lock.Unlock()
+
+	watcher, err = TOML.NewWatcher(TOML.NewStore[TOML2.ServerConfig](configFile))
+	if err != nil {
+		return err
+	}
+	watcher.File.Debounce = 200 * time.Millisecond
+	watcher.File.Polling = polling
+	watcher.File.PollInterval = 50 * time.Millisecond
+	watcher.OnReload = func(config *TOML2.ServerConfig) {
+		lock.Lock()
+		reloads++
+		hook := onReloadHook
+		lock.Unlock()
+		if hook != nil {
+			hook()
+		}
+	}
+	watcher.OnError = func(err error) {
+		lock.Lock()
+		defer lock.Unlock()
+		reloadErrors = append(reloadErrors, err)
+	}
+	return watcher.Start()
+}
+
+func copyFixture(fixture, target string) error {
+	bBytes, err := common.LoadFile(fixture)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(target, bBytes, 0644)
+}
+
+func aWatchedConfigFile(fixture string) error {
+	return watchConfigFile(fixture, false)
+}
+
+func aPolledConfigFile(fixture string) error {
+	if err := watchConfigFile(fixture, true); err != nil {
+		return err
+	}
+	if !watcher.File.IsPolling() {
+		return fmt.Errorf("expected the watcher to poll")
+	}
+	return nil
+}
+
+func theServedConfigHasTheName(name string) error {
+	if config := watcher.Get(); config == nil || config.Name != name {
+		return fmt.Errorf("expected the config [%v]; got [%v]", name, config)
+	}
+	return nil
+}
+
+func getReloads() int {
+	lock.Lock()
+	defer lock.Unlock()
+	return reloads
+}
+
+// wait for the expected number of reloads, then check the config served
+func theServedConfigHasTheNameAfterReloads(name string, expected int) error {
+	deadline := time.Now().Add(reloadTimeout)
+	for getReloads() < expected && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}	// end -- for (waiting)
+	// give an extra reload (e.g. of the same burst) the chance to show up
+	time.Sleep(400 * time.Millisecond)
+
+	if count := getReloads(); count != expected {
+		return fmt.Errorf("expected %v reload(s); got %v", expected, count)
+	}
+	return theServedConfigHasTheName(name)
+}
+
+func theConfigFileIsOverwrittenBy(fixture string) error {
+	return copyFixture(fixture, configFile)
+}
+
+// editors write a temp file and rename it over the original
+func theConfigFileIsSavedThroughARenameBy(fixture string) error {
+	tmpFile := configFile + ".swp"
+	if err := copyFixture(fixture, tmpFile); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, configFile)
+}
+
+func theConfigFileIsDeleted() error {
+	if err := os.Remove(configFile); err != nil {
+		return err
+	}
+	time.Sleep(300 * time.Millisecond)
+	return nil
+}
+
+// partial contents followed by the final contents
+func theConfigFileIsWrittenTimesInABurstEndingWith(times int, fixture string) error {
+	bBytes, err := common.LoadFile(fixture)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < times; i++ {
+		if err := os.WriteFile(configFile, bBytes[:len(bBytes) * i / times], 0644); err != nil {
+			return err
+		}
+	}	// end -- for (partial writes)
+	return os.WriteFile(configFile, bBytes, 0644)
+}
+
+func aReloadErrorIsReported() error {
+	deadline := time.Now().Add(reloadTimeout)
+	for time.Now().Before(deadline) {
+		lock.Lock()
+		count := len(reloadErrors)
+		lock.Unlock()
+		if count > 0 {
+			return nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}	// end -- for (waiting)
+	return fmt.Errorf("expected a reload error")
+}
+
+func noReloadErrorIsReported() error {
+	lock.Lock()
+	defer lock.Unlock()
+	if len(reloadErrors) > 0 {
+		return fmt.Errorf("expected no reload error; got %v", reloadErrors)
+	}
+	return nil
+}
+
+// Stop closes the notification; with the watcher busy reloading, the
+// pending change must not be reloaded through the polling fallback once
+// the watcher is stopped
+func stoppingTheWatcherDuringAReloadReloadsNothingElse(fixture string, times int) error {
+	for i := 0; i < times; i++ {
+		if i > 0 {
+			if err := watchConfigFile("hotReloadToml.toml", false); err != nil {
+				return err
+			}
+		}
+		reloading := make(chan struct{})
+		stopping := make(chan struct{})
+		var once sync.Once
+		lock.Lock()
+		onReloadHook = func() {
+			once.Do(func() {
+				// busy until the watcher is being stopped
+				close(reloading)
+				<-stopping
+				time.Sleep(50 * time.Millisecond)
+			})
+		}
+		lock.Unlock()
+
+		if err := copyFixture(fixture, configFile); err != nil {
+			return err
+		}
+		select {
+		case <-reloading:
+		case <-time.After(reloadTimeout):
+			return fmt.Errorf("expected a reload (attempt %v)", i + 1)
+		}
+		// a pending change while the watcher is stopped
+		if err := copyFixture("hotReloadToml.toml", configFile); err != nil {
+			return err
+		}
+		close(stopping)
+		watcher.Stop()
+		if count := getReloads(); count != 1 {
+			return fmt.Errorf("expected 1 reload before stopping the watcher; got %v (attempt %v)", count, i + 1)
+		}
+	}	// end -- for (attempts)
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^a watched config file "([^"]*)"$`, aWatchedConfigFile)
+	s.Step(`^a polled config file "([^"]*)"$`, aPolledConfigFile)
+	s.Step(`^the served config has the name "([^"]*)"$`, theServedConfigHasTheName)
+	s.Step(`^the served config has the name "([^"]*)" after (\d+) reloads?$`, theServedConfigHasTheNameAfterReloads)
+	s.Step(`^the config file is overwritten by "([^"]*)"$`, theConfigFileIsOverwrittenBy)
+	s.Step(`^the config file is saved through a rename by "([^"]*)"$`, theConfigFileIsSavedThroughARenameBy)
+	s.Step(`^the config file is deleted$`, theConfigFileIsDeleted)
+	s.Step(`^the config file is written (\d+) times in a burst ending with "([^"]*)"$`, theConfigFileIsWrittenTimesInABurstEndingWith)
+	s.Step(`^stopping the watcher during the reload of "([^"]*)" reloads nothing else for (\d+) times$`, stoppingTheWatcherDuringAReloadReloadsNothingElse)
+	s.Step(`^a reload error is reported$`, aReloadErrorIsReported)
+	s.Step(`^no reload error is reported$`, noReloadErrorIsReported)
+}
@@ -0,0 +1,39 @@
+Feature: TOML lifecycle hooks
+  Structs could optionally implement the BeforeLoad, AfterLoad, BeforeSave
+  and AfterSave hooks (check interfaces/IConfig.go); values could then be
+  normalised after loading or derived before saving.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - hooks are invoked on the root Struct and every child Struct
+  - Before hooks run top-down; After hooks run bottom-up
+  - AfterLoad runs before the validation (normalised values are validated)
+  - defaults set by the BeforeLoad hooks of child Structs are kept
+
+  Scenario: 1) Normalise values after loading
+    Given there is a TOML in the current folder named "lifeCycleToml.toml"
+    When I load the TOML file named "lifeCycleToml.toml"
+    Then the hooks invoked are "BeforeLoad:ServerConfig,BeforeLoad:ServerLimits,AfterLoad:ServerLimits,AfterLoad:ServerTLS,AfterLoad:ServerConfig"
+    And the value for field "name" is "order-service"
+    And the value for field "hostname" is "orders.example.com"
+    And the value for field "tls.certFile" is "/etc/ssl/order-service.crt"
+    And the value for field "tls.keyFile" is "/opt/keys/order-service.key"
+    And the value for field "limits.minWorkers" is "1"
+
+  Scenario: 2) Derive values before saving
+    Given there is a TOML in the current folder named "lifeCycleToml.toml"
+    When I load the TOML file named "lifeCycleToml.toml"
+    And reset the minimum workers
+    And save changes to the "lifeCycleToml_test.toml"
+    Then the hooks invoked are "BeforeSave:ServerConfig,BeforeSave:ServerLimits,AfterSave:ServerConfig"
+    And the value for field "limits.minWorkers" is "1"
+    And finally reload the configuration file "lifeCycleToml_test.toml", "limits.minWorkers" should equals to "1"
+
+  Scenario: 3) Default values of child Structs before loading
+    Given there is a TOML in the current folder named "lifeCycleToml.toml"
+    When I load the TOML file named "lifeCycleToml.toml"
+    Then the value for field "limits.timeout" is "10"
+    And the value for field "limits.maxWorkers" is "16"
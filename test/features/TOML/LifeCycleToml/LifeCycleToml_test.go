@@ -0,0 +1,125 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the lifecycle hooks (BeforeLoad, AfterLoad, BeforeSave and AfterSave)
+package LifeCycleToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.ServerConfig
+
+func gotTomlFileName(tomlFile string) error {
+	if len(tomlFile)>0 {
+		configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.ServerConfig{}))
+		return nil
+	}
+	return fmt.Errorf("the given 'name' is not Valid (%v)", tomlFile)
+}
+
+func loadTomlFile(_ string) error {
+	configObject = TOML2.ServerConfig{}
+	TOML2.HookTrace = []string{}
+
+	_, err := configReader.Load(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func theHooksInvokedAre(hooks string) error {
+	actual := strings.Join(TOML2.HookTrace, ",")
+	if strings.Compare(actual, hooks) != 0 {
+		return fmt.Errorf("expected hooks [%v] BUT got [%v]", hooks, actual)
+	}
+	return nil
+}
+
+func getFieldValue(object TOML2.ServerConfig, field string) string {
+	switch field {
+	case "name":
+		return object.Name
+	case "hostname":
+		return object.Hostname
+	case "tls.certFile":
+		return object.TLS.CertFile
+	case "tls.keyFile":
+		return object.TLS.KeyFile
+	case "limits.minWorkers":
+		return strconv.Itoa(object.Limits.MinWorkers)
+	case "limits.maxWorkers":
+		return strconv.Itoa(object.Limits.MaxWorkers)
+	case "limits.timeout":
+		return strconv.FormatFloat(object.Limits.Timeout, 'g', -1, 64)
+	}
+	return fmt.Sprintf("unsupported field [%v]", field)
+}
+
+func theValueForFieldIs(field, value string) error {
+	if actual := getFieldValue(configObject, field); strings.Compare(actual, value) != 0 {
+		return fmt.Errorf("for field '%v', expected '%v' but got '%v'", field, value, actual)
+	}
+	return nil
+}
+
+func resetTheMinimumWorkers() error {
+	configObject.Limits.MinWorkers = 0
+	return nil
+}
+
+func saveChangesToToml(tomlFile string) error {
+	TOML2.HookTrace = []string{}
+	// save through the pointer so that the derived values are visible
+	err := configReader.Save(tomlFile, &configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+func reconciliationOnFieldsSet(filename, field, value string) error {
+	configReader.Name = filename
+	configObject2 := TOML2.ServerConfig{}
+
+	if _, err := configReader.Load(&configObject2); err != nil {
+		return fmt.Errorf("something wrong when loading the config file %v => %v\n", filename, err)
+	}
+	if actual := getFieldValue(configObject2, field); strings.Compare(actual, value) != 0 {
+		return fmt.Errorf("expected value to be [%v] BUT have [%v]\n", value, actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, gotTomlFileName)
+	s.Step(`^I load the TOML file named "([^"]*)"$`, loadTomlFile)
+	s.Step(`^the hooks invoked are "([^"]*)"$`, theHooksInvokedAre)
+	s.Step(`^the value for field "([^"]*)" is "([^"]*)"$`, theValueForFieldIs)
+	s.Step(`^reset the minimum workers$`, resetTheMinimumWorkers)
+	s.Step(`^save changes to the "([^"]*)"$`, saveChangesToToml)
+	s.Step(`^finally reload the configuration file "([^"]*)", "([^"]*)" should equals to "([^"]*)"$`, reconciliationOnFieldsSet)
+}
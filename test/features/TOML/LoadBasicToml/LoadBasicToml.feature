@@ -40,3 +40,13 @@ Feature: TOML Access (Basic use cases)
     And the array value for field "time" "author.registrationDates" at index "1" is "2009-02-14" cap is "2"
     And the array value for field "time" "specialDates" at index "1" is "2009-12-22" cap is "3"
     And the array value for field "time" "specialDates" at index "0" is "2016-12-25T14:02:59+08:00" cap is "3"
+
+  Scenario: Load TOML plus the decode meta data (defined, undecoded and defaulted keys)
+    Given there is a TOML in the current folder named "loadBasicTomlMetaData.toml"
+    When I load the TOML file named "loadBasicTomlMetaData.toml" with meta data
+    Then the key "version" is defined at line 1
+    And the key "activeProfile" is defined at line 4
+    And the key "author.age" is defined at line 7
+    And the key "author.nickName" is undecoded at line 8
+    And the key "workingHoursDay" is defaulted
+    And the key "author.birthday" is defaulted
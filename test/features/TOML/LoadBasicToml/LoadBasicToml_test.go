@@ -36,6 +36,7 @@ import (
 // class level variable
 var configReader TOML.TOMLConfigImpl
 var config TOML2.DemoTOMLConfig
+var metaData common.DecodeMetaData
 
 func foundATomlFileLocation(name string) error {
 	// somehow you need to know the target Config object/struct's type
@@ -297,10 +298,58 @@ func theTimeArrayValueForFieldAtIndexIsCapIs(field string, arrayIdx int, value s
 	return fmt.Errorf("field [%v] does not matches with {%v}; value got is (%v) / size might also not match {%v} vs [%v]", field, value, actualVal, arraySize, actualArrSize)
 }
 
+/* ------------------------------------------------------------ */
+/*	scenario) Load the TOML plus the decode meta data			*/
+/* ------------------------------------------------------------ */
+
+func loadTomlWithMetaData(name string) error {
+	configObject := TOML2.DemoTOMLConfig{ Author: TOML2.Author{} }
+
+	_, mData, err := configReader.LoadWithMetaData(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	config = configObject
+	metaData = mData
+
+	return nil
+}
+
+func theKeyIsDefinedAtLine(key string, line int) error {
+	if !metaData.IsDefined(key) {
+		return fmt.Errorf("key [%v] is expected to be defined; defined keys are %v", key, metaData.DefinedKeys())
+	}
+	if metaData.GetLine(key) != line {
+		return fmt.Errorf("key [%v] is expected to be defined at line %v BUT got line %v", key, line, metaData.GetLine(key))
+	}
+	return nil
+}
+
+func theKeyIsUndecodedAtLine(key string, line int) error {
+	if !metaData.IsUndecoded(key) {
+		return fmt.Errorf("key [%v] is expected to be undecoded; undecoded keys are %v", key, metaData.UndecodedKeys())
+	}
+	if metaData.GetLine(key) != line {
+		return fmt.Errorf("key [%v] is expected to be found at line %v BUT got line %v", key, line, metaData.GetLine(key))
+	}
+	return nil
+}
+
+func theKeyIsDefaulted(key string) error {
+	if metaData.IsDefined(key) || !metaData.IsDefaulted(key) {
+		return fmt.Errorf("key [%v] is expected to be defaulted; defaulted keys are %v", key, metaData.Defaulted)
+	}
+	return nil
+}
+
 // testing the features of this BDD story use case
 func FeatureContext(s *godog.Suite) {
 	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, foundATomlFileLocation)
 	s.Step(`^I load the TOML file named "([^"]*)"$`, loadToml)
+	s.Step(`^I load the TOML file named "([^"]*)" with meta data$`, loadTomlWithMetaData)
+	s.Step(`^the key "([^"]*)" is defined at line (\d+)$`, theKeyIsDefinedAtLine)
+	s.Step(`^the key "([^"]*)" is undecoded at line (\d+)$`, theKeyIsUndecodedAtLine)
+	s.Step(`^the key "([^"]*)" is defaulted$`, theKeyIsDefaulted)
 	s.Step(`^I should be able to access the fields from this toml file$`, iShouldBeAbleToAccessTheFieldsFromThisTomlFile)
 	s.Step(`^the value for field "([^"]*)" is "([^"]*)"$`, checkFieldValue)
 	s.Step(`^the integer value for field "([^"]*)" is (\d+)$`, theIntegerValueForFieldIs)
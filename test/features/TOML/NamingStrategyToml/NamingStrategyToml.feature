@@ -0,0 +1,51 @@
+Feature: TOML key naming strategy
+  Fields without a toml Tag could be keyed automatically through a naming
+  strategy set on TOMLConfigImpl (exact, camelCase, snake_case, kebab-case
+  or case-insensitive); fields with a toml Tag keep their Tag's key.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - child Struct(s) without a toml Tag are prefixed by the derived key
+  - without a naming strategy, fields without a toml Tag are ignored
+
+  Scenario: 1) Load with the camelCase strategy
+    Given the naming strategy "camelCase" and a TOML named "namingStrategyCamel.toml"
+    When I load the TOML file
+    Then the loaded values are "session-cache,1000,8080,true,eu-west,redis.internal,2.5"
+
+  Scenario: 2) Load with the snake_case strategy
+    Given the naming strategy "snake_case" and a TOML named "namingStrategySnake.toml"
+    When I load the TOML file
+    Then the loaded values are "session-cache,1000,8080,true,eu-west,redis.internal,2.5"
+
+  Scenario: 3) Load with the kebab-case strategy
+    Given the naming strategy "kebab-case" and a TOML named "namingStrategyKebab.toml"
+    When I load the TOML file
+    Then the loaded values are "session-cache,1000,8080,true,eu-west,redis.internal,2.5"
+
+  Scenario: 4) Load with the exact strategy
+    Given the naming strategy "exact" and a TOML named "namingStrategyExact.toml"
+    When I load the TOML file
+    Then the loaded values are "session-cache,1000,8080,true,eu-west,redis.internal,2.5"
+
+  Scenario: 5) Load with the case-insensitive strategy
+    Given the naming strategy "case-insensitive" and a TOML named "namingStrategyMixedCase.toml"
+    When I load the TOML file
+    Then the loaded values are "session-cache,1000,8080,true,eu-west,redis.internal,2.5"
+    And no keys are defaulted
+
+  Scenario: 6) No naming strategy
+    Given the naming strategy "none" and a TOML named "namingStrategyCamel.toml"
+    When I load the TOML file
+    Then the loaded values are ",0,0,false,eu-west,,0"
+    And the key "serviceName" is undecoded
+
+  Scenario: 7) Save with the snake_case strategy
+    Given the naming strategy "snake_case" and a TOML named "namingStrategySnake.toml"
+    When I load the TOML file
+    And save changes to the "namingStrategy_test.toml"
+    Then the file "namingStrategy_test.toml" contains the keys "service_name,max_entries,http_port,eviction_enabled,cache.region,backend.host_name,backend.timeout_seconds"
+    And finally reload the configuration file "namingStrategy_test.toml", the loaded values are "session-cache,1000,8080,true,eu-west,redis.internal,2.5"
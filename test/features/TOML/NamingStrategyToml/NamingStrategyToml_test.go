@@ -0,0 +1,146 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the key naming strategies (fields without toml Tags)
+package NamingStrategyToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.CacheConfig
+var metaData common.DecodeMetaData
+
+var strategies = map[string]common.KeyNamingStrategy{
+	"none": common.NamingNone,
+	"exact": common.NamingExact,
+	"camelCase": common.NamingCamelCase,
+	"snake_case": common.NamingSnakeCase,
+	"kebab-case": common.NamingKebabCase,
+	"case-insensitive": common.NamingCaseInsensitive,
+}
+
+func gotStrategyAndTomlFileName(strategy, tomlFile string) error {
+	namingStrategy, ok := strategies[strategy]
+	if !ok {
+		return fmt.Errorf("unknown naming strategy [%v]", strategy)
+	}
+	configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.CacheConfig{}))
+	configReader.NamingStrategy = namingStrategy
+	return nil
+}
+
+func loadTomlFile() error {
+	var err error
+	configObject = TOML2.CacheConfig{}
+
+	_, metaData, err = configReader.LoadWithMetaData(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func getLoadedValues(object TOML2.CacheConfig) string {
+	return fmt.Sprintf("%v,%v,%v,%v,%v,%v,%v",
+		object.ServiceName, object.MaxEntries, object.HTTPPort, object.EvictionEnabled,
+		object.Region, object.Backend.HostName, object.Backend.TimeoutSeconds)
+}
+
+func theLoadedValuesAre(values string) error {
+	if actual := getLoadedValues(configObject); strings.Compare(actual, values) != 0 {
+		return fmt.Errorf("expected values [%v] but got [%v]", values, actual)
+	}
+	return nil
+}
+
+func noKeysAreDefaulted() error {
+	if len(metaData.Defaulted) > 0 {
+		return fmt.Errorf("expected no defaulted keys but got %v", metaData.Defaulted)
+	}
+	return nil
+}
+
+func theKeyIsUndecoded(key string) error {
+	if !metaData.IsUndecoded(key) {
+		return fmt.Errorf("the key [%v] should be undecoded; undecoded keys => %v", key, metaData.UndecodedKeys())
+	}
+	return nil
+}
+
+func saveChangesToToml(tomlFile string) error {
+	err := configReader.Save(tomlFile, configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+func theFileContainsTheKeys(filename, keys string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	keysInFile := make(map[string]bool)
+	table := ""
+	for _, line := range strings.Split(string(bContent), "\n") {
+		if strings.HasPrefix(line, "[") {
+			table = strings.Trim(line, "[] ") + "."
+		} else if idx := strings.Index(line, "="); idx > 0 {
+			keysInFile[table + strings.TrimSpace(line[:idx])] = true
+		}
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if !keysInFile[key] {
+			return fmt.Errorf("the key [%v] should be saved to [%v]", key, filename)
+		}
+	}
+	return nil
+}
+
+func reconciliationOnValues(filename, values string) error {
+	configReader.Name = filename
+	configObject2 := TOML2.CacheConfig{}
+
+	if _, err := configReader.Load(&configObject2); err != nil {
+		return fmt.Errorf("something wrong when loading the config file %v => %v\n", filename, err)
+	}
+	if actual := getLoadedValues(configObject2); strings.Compare(actual, values) != 0 {
+		return fmt.Errorf("expected values [%v] BUT have [%v]\n", values, actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^the naming strategy "([^"]*)" and a TOML named "([^"]*)"$`, gotStrategyAndTomlFileName)
+	s.Step(`^I load the TOML file$`, loadTomlFile)
+	s.Step(`^the loaded values are "([^"]*)"$`, theLoadedValuesAre)
+	s.Step(`^no keys are defaulted$`, noKeysAreDefaulted)
+	s.Step(`^the key "([^"]*)" is undecoded$`, theKeyIsUndecoded)
+	s.Step(`^save changes to the "([^"]*)"$`, saveChangesToToml)
+	s.Step(`^the file "([^"]*)" contains the keys "([^"]*)"$`, theFileContainsTheKeys)
+	s.Step(`^finally reload the configuration file "([^"]*)", the loaded values are "([^"]*)"$`, reconciliationOnValues)
+}
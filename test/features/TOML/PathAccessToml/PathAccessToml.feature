@@ -0,0 +1,40 @@
+Feature: TOML typed access by dotted path
+  TOML.Get[T] returns the value identified by a dotted path as type T;
+  child Structs of any depth, maps, slices and indices (e.g. hobbies[1])
+  are supported and a missing path returns a not-found error.
+
+  Assumptions for the feature test:
+  - the config Structs are populated in memory
+
+  Major use cases:
+  - read values of nested Structs, maps and arrays
+  - numeric values could be read as another numeric type (unless they
+    overflow the type or lose their fraction)
+  - missing paths and type mismatches are errors
+
+  Scenario: 1) Read nested values
+    Given an in-memory transaction record
+    Then the path "client.address.city" yields the string "Seoul"
+    And the path "client.address.geopoint.Lat" yields the float "37.5326"
+    And the path "client.address.geopoint.LatLonArr[1]" yields the float "127.024612"
+    And the path "broker.licences[2]" yields the string "it-approved"
+    And the path "amount" yields the float "2359.91"
+
+  Scenario: 2) Read map entries and indices
+    Given an in-memory server inventory
+    Then the path "labels.team" yields the string "ops"
+    And the path "regions.asia.zones[0]" yields the string "hk-1"
+    And the path "regions.asia.capacity" yields the float "12"
+
+  Scenario: 3) Missing paths and type mismatches
+    Given an in-memory transaction record
+    Then the path "client.address.zipcode" is not found
+    And the path "broker.licences[9]" is not found
+    And the path "client.fullname[0]" is invalid
+    And reading the path "client.address.city" as an integer fails
+
+  Scenario: 4) Numeric overflows and truncations
+    Given an in-memory server inventory
+    Then the path "regions.asia.capacity" yields the int8 12
+    And reading the path "regions.europe.capacity" as an int8 fails
+    And reading the path "regions.europe.load" as an int8 fails
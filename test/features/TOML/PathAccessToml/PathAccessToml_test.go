@@ -0,0 +1,146 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the typed access by dotted path
+package PathAccessToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configObject interface{}
+
+func anInMemoryTransactionRecord() error {
+	record := TOML2.TransactionRecord{}
+	record.Amount = 2359.91
+	record.Client.FullName = "Jackie Kim"
+	record.Client.Address.City = "Seoul"
+	record.Client.Address.GeoPoint.Lat = 37.5326
+	record.Client.Address.GeoPoint.LatLonArr = []float64{ 37.5326, 127.024612 }
+	record.Broker.Licences = []string{ "audit-approved", "cpa-approved", "it-approved" }
+	configObject = &record
+	return nil
+}
+
+func anInMemoryServerInventory() error {
+	configObject = TOML2.ServerInventory{
+		Name: "inventory",
+		Labels: map[string]string{ "team": "ops" },
+		Regions: map[string]interface{}{
+			"asia": map[string]interface{}{
+				"zones": []string{ "hk-1", "sg-1" },
+				"capacity": 12,
+			},
+			"europe": map[string]interface{}{
+				"capacity": 1000,
+				"load": 0.75,
+			},
+		},
+	}
+	return nil
+}
+
+func thePathYieldsTheString(path, expected string) error {
+	value, err := TOML.Get[string](configObject, path)
+	if err != nil {
+		return err
+	}
+	if strings.Compare(value, expected) != 0 {
+		return fmt.Errorf("expected [%v] for the path [%v]; got [%v]", expected, path, value)
+	}
+	return nil
+}
+
+func thePathYieldsTheFloat(path, expected string) error {
+	fExpected, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return err
+	}
+	value, err := TOML.Get[float64](configObject, path)
+	if err != nil {
+		return err
+	}
+	// float32 fields are compared at float32 precision
+	if float32(value) != float32(fExpected) {
+		return fmt.Errorf("expected [%v] for the path [%v]; got [%v]", expected, path, value)
+	}
+	return nil
+}
+
+func thePathIsNotFound(path string) error {
+	_, err := TOML.Get[interface{}](configObject, path)
+	if !errors.Is(err, common.ErrPathNotFound) {
+		return fmt.Errorf("expected a not found error for the path [%v]; got [%v]", path, err)
+	}
+	return nil
+}
+
+func thePathIsInvalid(path string) error {
+	_, err := TOML.Get[interface{}](configObject, path)
+	if err == nil || errors.Is(err, common.ErrPathNotFound) {
+		return fmt.Errorf("expected an invalid path error for the path [%v]; got [%v]", path, err)
+	}
+	return nil
+}
+
+func readingThePathAsAnIntegerFails(path string) error {
+	if value, err := TOML.Get[int](configObject, path); err == nil {
+		return fmt.Errorf("expected an error on reading the path [%v] as int; got [%v]", path, value)
+	}
+	return nil
+}
+
+func thePathYieldsTheInt8(path string, expected int) error {
+	value, err := TOML.Get[int8](configObject, path)
+	if err != nil {
+		return err
+	}
+	if int(value) != expected {
+		return fmt.Errorf("expected [%v] for the path [%v]; got [%v]", expected, path, value)
+	}
+	return nil
+}
+
+func readingThePathAsAnInt8Fails(path string) error {
+	value, err := TOML.Get[int8](configObject, path)
+	var pathErr *common.PathError
+	if !errors.As(err, &pathErr) {
+		return fmt.Errorf("expected a PathError on reading the path [%v] as int8; got [%v] (%v)", path, err, value)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^an in-memory transaction record$`, anInMemoryTransactionRecord)
+	s.Step(`^an in-memory server inventory$`, anInMemoryServerInventory)
+	s.Step(`^the path "([^"]*)" yields the string "([^"]*)"$`, thePathYieldsTheString)
+	s.Step(`^the path "([^"]*)" yields the float "([^"]*)"$`, thePathYieldsTheFloat)
+	s.Step(`^the path "([^"]*)" is not found$`, thePathIsNotFound)
+	s.Step(`^the path "([^"]*)" is invalid$`, thePathIsInvalid)
+	s.Step(`^reading the path "([^"]*)" as an integer fails$`, readingThePathAsAnIntegerFails)
+	s.Step(`^the path "([^"]*)" yields the int8 (\d+)$`, thePathYieldsTheInt8)
+	s.Step(`^reading the path "([^"]*)" as an int8 fails$`, readingThePathAsAnInt8Fails)
+}
@@ -0,0 +1,52 @@
+Feature: TOML predicate matching
+  TOML.Match evaluates a predicate expression over key paths against a
+  loaded config Struct e.g. "role in [admin, ops]", "workingHoursDay > 6",
+  "hobbies contains guitar"; values are coerced into the type of the path's
+  value hence ints, floats, strings, bools, times and slices are supported.
+
+  Assumptions for the feature test:
+  - the toml file is loaded into a DemoTOMLConfig Struct
+
+  Major use cases:
+  - compare values of any supported type with ==, !=, >, >=, <, <=
+  - check membership with "in" and "contains"
+  - combine predicates with and, or, not and parentheses
+  - invalid expressions and unresolvable paths are errors
+
+  Scenario: 1) Compare values
+    Given the toml file "predicateToml.toml" is loaded
+    Then the predicate "role == admin" is true
+    And the predicate "version != '1.1.0a'" is false
+    And the predicate "workingHoursDay > 6" is true
+    And the predicate "author.age <= 24" is false
+    And the predicate "author.height >= 167.5" is true
+    And the predicate "activeProfile" is true
+    And the predicate "author.birthday < 1990-03-01" is true
+    And the predicate "lastUpdateTime == 2016-12-25T06:02:59Z" is true
+    And the predicate "hobbies[2] == guitar" is true
+
+  Scenario: 2) Membership on values and arrays
+    Given the toml file "predicateToml.toml" is loaded
+    Then the predicate "role in [admin, ops]" is true
+    And the predicate "workingHoursDay in [6, 7]" is false
+    And the predicate "hobbies contains guitar" is true
+    And the predicate "taskNumbers contains 2451" is true
+    And the predicate "author.likes contains true" is true
+    And the predicate "specialDates contains 2009-12-22" is true
+    And the predicate "author.luckyNumbers == [12, 89]" is true
+
+  Scenario: 3) Combine predicates
+    Given the toml file "predicateToml.toml" is loaded
+    Then the predicate "role in [admin, ops] and workingHoursDay > 6" is true
+    And the predicate "role == guest or not (hobbies contains soccer)" is true
+    And the predicate "!activeProfile || author.firstName == 'Jason Wong'" is false
+    And the IsMatched predicate "author.lastName == Wong && author.age > 20" is true
+
+  Scenario: 4) Invalid predicates
+    Given the toml file "predicateToml.toml" is loaded
+    Then the predicate "role = admin" is invalid
+    And the predicate "(role == admin" is invalid
+    And the predicate "role in admin" is invalid
+    And evaluating the predicate "zipcode == 123" fails
+    And evaluating the predicate "workingHoursDay > eight" fails
+    And evaluating the predicate "activeProfile > false" fails
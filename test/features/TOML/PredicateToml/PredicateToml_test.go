@@ -0,0 +1,84 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the predicate matching
+package PredicateToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"reflect"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.DemoTOMLConfig
+
+func theTomlFileIsLoaded(name string) error {
+	configReader = TOML.NewTOMLConfigImpl(name, reflect.TypeOf(TOML2.DemoTOMLConfig{}))
+	configObject = TOML2.DemoTOMLConfig{}
+	_, err := configReader.Load(&configObject)
+	return err
+}
+
+func checkPredicate(expression, expected string, matched bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if fmt.Sprintf("%v", matched) != expected {
+		return fmt.Errorf("expected the predicate [%v] to be %v", expression, expected)
+	}
+	return nil
+}
+
+func thePredicateIs(expression, expected string) error {
+	matched, err := TOML.Match(&configObject, expression)
+	return checkPredicate(expression, expected, matched, err)
+}
+
+func theIsMatchedPredicateIs(expression, expected string) error {
+	matched, err := configReader.IsMatched(configObject, expression)
+	return checkPredicate(expression, expected, matched, err)
+}
+
+func thePredicateIsInvalid(expression string) error {
+	if _, err := TOML.ParsePredicate(expression); err == nil {
+		return fmt.Errorf("expected the predicate [%v] to be invalid", expression)
+	}
+	return nil
+}
+
+func evaluatingThePredicateFails(expression string) error {
+	predicate, err := TOML.ParsePredicate(expression)
+	if err != nil {
+		return err
+	}
+	if _, err := predicate.Evaluate(&configObject); err == nil {
+		return fmt.Errorf("expected an error on evaluating the predicate [%v]", expression)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^the toml file "([^"]*)" is loaded$`, theTomlFileIsLoaded)
+	s.Step(`^the predicate "([^"]*)" is (true|false)$`, thePredicateIs)
+	s.Step(`^the IsMatched predicate "([^"]*)" is (true|false)$`, theIsMatchedPredicateIs)
+	s.Step(`^the predicate "([^"]*)" is invalid$`, thePredicateIsInvalid)
+	s.Step(`^evaluating the predicate "([^"]*)" fails$`, evaluatingThePredicateFails)
+}
@@ -0,0 +1,38 @@
+Feature: TOML comment and format preserving save
+  With TOMLConfigImpl.PreserveFormat set, Load remembers the file's
+  comments, blank lines, whitespace and key order; saving back to the same
+  file rewrites the changed values only and leaves the rest untouched.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+  - the file is copied before the test as it is written back
+
+  Major use cases:
+  - only the changed value is rewritten; inline comments are kept
+  - missing keys are added next to the related keys or into their [table]
+
+  Scenario: 1) Update a single value
+    Given a copy of "preserveFormatToml.toml" named "preserveFormatToml_test.toml"
+    When I load the TOML file named "preserveFormatToml_test.toml" preserving the format
+    And update the field "lastUpdateTime" to "2018-05-01T11:59:59+08:00"
+    And save back to the same file
+    Then the file "preserveFormatToml_test.toml" equals to the file "preserveFormatTomlExpected.toml"
+
+  Scenario: 2) Update and add child values
+    Given a copy of "preserveFormatToml.toml" named "preserveFormatTomlChild_test.toml"
+    When I load the TOML file named "preserveFormatTomlChild_test.toml" preserving the format
+    And update the field "author.age" to "26"
+    And update the field "author.lastName" to "Wong"
+    And save back to the same file
+    Then the file "preserveFormatTomlChild_test.toml" equals to the file "preserveFormatTomlChildExpected.toml"
+
+  Scenario: 3) Update and add values with tables
+    Given a copy of "preserveFormatTomlTables.toml" named "preserveFormatTomlTables_test.toml"
+    When I load the TOML file named "preserveFormatTomlTables_test.toml" preserving the format
+    And update the field "role" to "user"
+    And update the field "workingHoursDay" to "9"
+    And update the field "author.firstName" to "Jay"
+    And update the field "author.age" to "30"
+    And save back to the same file
+    Then the file "preserveFormatTomlTables_test.toml" equals to the file "preserveFormatTomlTablesExpected.toml"
@@ -0,0 +1,108 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the comment and format preserving save
+package PreserveFormatToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.DemoTOMLConfig
+
+func aCopyOfNamed(source, target string) error {
+	bContent, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(target, bContent, 0644)
+}
+
+func loadTomlFilePreservingTheFormat(tomlFile string) error {
+	configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.DemoTOMLConfig{}))
+	configReader.PreserveFormat = true
+	configObject = TOML2.DemoTOMLConfig{}
+
+	_, err := configReader.Load(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func updateTheFieldTo(field, value string) error {
+	var err error
+	switch field {
+	case "role":
+		configObject.Role = value
+	case "workingHoursDay":
+		configObject.WorkingHoursDay, err = strconv.Atoi(value)
+	case "lastUpdateTime":
+		configObject.LastUpdateTime, err = time.Parse(common.TimeDefault, value)
+	case "author.firstName":
+		configObject.Author.FirstName = value
+	case "author.lastName":
+		configObject.Author.LastName = value
+	case "author.age":
+		configObject.Author.Age, err = strconv.Atoi(value)
+	default:
+		err = fmt.Errorf("unsupported field [%v]", field)
+	}
+	return err
+}
+
+func saveBackToTheSameFile() error {
+	err := configReader.Save(configReader.Name, configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+func theFileEqualsToTheFile(filename, expectedFilename string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the file [%v] differs from [%v]; got =>\n%v", filename, expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^a copy of "([^"]*)" named "([^"]*)"$`, aCopyOfNamed)
+	s.Step(`^I load the TOML file named "([^"]*)" preserving the format$`, loadTomlFilePreservingTheFormat)
+	s.Step(`^update the field "([^"]*)" to "([^"]*)"$`, updateTheFieldTo)
+	s.Step(`^save back to the same file$`, saveBackToTheSameFile)
+	s.Step(`^the file "([^"]*)" equals to the file "([^"]*)"$`, theFileEqualsToTheFile)
+}
@@ -0,0 +1,46 @@
+Feature: TOML deterministic save output
+  Save writes the keys in Struct field order (or sorted alphabetically if
+  TOMLConfigImpl.SortKeys is set); scalars are always written before the
+  tables (child Structs), hence saving the same values twice yields the
+  same file.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - keys follow the Struct field order regardless of the order in the file
+  - keys could be sorted alphabetically instead
+
+  Scenario: 1) Save in Struct field order
+    Given there is a TOML in the current folder named "saveOrderToml.toml"
+    When I load the TOML file named "saveOrderToml.toml"
+    And save changes to the "saveOrderToml_test.toml"
+    Then the file "saveOrderToml_test.toml" equals to the file "saveOrderTomlExpected.toml"
+
+  Scenario: 2) Save with sorted keys
+    Given there is a TOML in the current folder named "saveOrderToml.toml"
+    When I load the TOML file named "saveOrderToml.toml"
+    And sort the keys on save
+    And save changes to the "saveOrderTomlSorted_test.toml"
+    Then the file "saveOrderTomlSorted_test.toml" equals to the file "saveOrderTomlSortedExpected.toml"
+
+  Scenario: 3) Save twice yields the same file
+    Given there is a TOML in the current folder named "saveOrderToml.toml"
+    When I load the TOML file named "saveOrderToml.toml"
+    And save changes to the "saveOrderToml_test.toml"
+    And save changes to the "saveOrderTomlAgain_test.toml"
+    Then the file "saveOrderTomlAgain_test.toml" equals to the file "saveOrderToml_test.toml"
+
+  Scenario: 4) Save with dotted keys
+    Given there is a TOML in the current folder named "saveOrderToml.toml"
+    When I load the TOML file named "saveOrderToml.toml"
+    And keep the dotted keys on save
+    And save changes to the "saveOrderTomlDotted_test.toml"
+    Then the file "saveOrderTomlDotted_test.toml" equals to the file "saveOrderTomlDottedExpected.toml"
+
+  Scenario: 5) Save nested maps as tables
+    Given there is a TOML in the current folder named "saveOrderTomlMaps_test.toml"
+    When an in-memory inventory with nested maps
+    And save the inventory to the "saveOrderTomlMaps_test.toml"
+    Then the file "saveOrderTomlMaps_test.toml" equals to the file "saveOrderTomlMapsExpected.toml"
@@ -0,0 +1,116 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the save output order (Struct field order or sorted keys)
+package SaveOrderToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.ServerConfig
+var inventory TOML2.ServerInventory
+
+func gotTomlFileName(tomlFile string) error {
+	if len(tomlFile)>0 {
+		configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.ServerConfig{}))
+		return nil
+	}
+	return fmt.Errorf("the given 'name' is not Valid (%v)", tomlFile)
+}
+
+func loadTomlFile(_ string) error {
+	configObject = TOML2.ServerConfig{}
+
+	_, err := configReader.Load(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func sortTheKeysOnSave() error {
+	configReader.SortKeys = true
+	return nil
+}
+
+func keepTheDottedKeysOnSave() error {
+	configReader.DottedKeys = true
+	return nil
+}
+
+func saveChangesToToml(tomlFile string) error {
+	err := configReader.Save(tomlFile, configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+func anInMemoryInventoryWithNestedMaps() error {
+	inventory = TOML2.ServerInventory{
+		Name: "inventory",
+		Labels: map[string]string{ "tier": "gold", "team": "orders" },
+		Regions: map[string]interface{}{
+			"us": map[string]interface{}{ "primary": "ohio", "weight": 2 },
+			"eu": map[string]interface{}{ "zones": []string{ "a", "b" }, "primary": "dublin" },
+		},
+	}
+	return nil
+}
+
+func saveTheInventoryToToml(tomlFile string) error {
+	err := configReader.Save(tomlFile, inventory)
+	if err != nil {
+		return fmt.Errorf("could NOT save the inventory => %v", err)
+	}
+	return nil
+}
+
+func theFileEqualsToTheFile(filename, expectedFilename string) error {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the file [%v] differs from [%v]; got =>\n%v", filename, expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, gotTomlFileName)
+	s.Step(`^I load the TOML file named "([^"]*)"$`, loadTomlFile)
+	s.Step(`^sort the keys on save$`, sortTheKeysOnSave)
+	s.Step(`^keep the dotted keys on save$`, keepTheDottedKeysOnSave)
+	s.Step(`^save changes to the "([^"]*)"$`, saveChangesToToml)
+	s.Step(`^an in-memory inventory with nested maps$`, anInMemoryInventoryWithNestedMaps)
+	s.Step(`^save the inventory to the "([^"]*)"$`, saveTheInventoryToToml)
+	s.Step(`^the file "([^"]*)" equals to the file "([^"]*)"$`, theFileEqualsToTheFile)
+}
@@ -0,0 +1,66 @@
+Feature: TOML runtime set by dotted path
+  TOML.Set updates the value identified by a dotted path (any depth, maps
+  and indices included); string values are coerced into the field's type
+  with the same rules as loading, hence admin endpoints and CLIs could
+  update configs generically.
+
+  Assumptions for the feature test:
+  - the config Structs are populated in memory
+
+  Major use cases:
+  - set values of nested Structs, arrays and maps from strings
+  - set values of the field's own type
+  - conversion failures and unknown paths are errors
+  - numbers overflowing the field's type or losing their fraction are errors
+
+  Scenario: 1) Set values from strings
+    Given an in-memory demo config
+    When I set the path "author.birthday" to "1990-02-28"
+    And I set the path "author.age" to "30"
+    And I set the path "author.height" to "175.5"
+    And I set the path "hobbies" to "[reading, chess]"
+    And I set the path "hobbies[1]" to "go"
+    And I set the path "activeProfile" to "true"
+    Then the path "author.birthday" reads "1990-02-28 00:00:00 +0000 UTC"
+    And the path "author.age" reads "30"
+    And the path "author.height" reads "175.5"
+    And the path "hobbies" reads "[reading go]"
+    And the path "activeProfile" reads "true"
+
+  Scenario: 2) Set map entries
+    Given an in-memory server inventory
+    When I set the path "labels.team" to "dev"
+    And I set the path "regions.asia.capacity" to "20"
+    Then the path "labels.team" reads "dev"
+    And the path "regions.asia.capacity" reads "20"
+    And the value of the path "regions.asia.capacity" is an int
+
+  Scenario: 3) Set values of the field's own type
+    Given an in-memory demo config
+    When I set the path "workingHoursDay" to the integer 6
+    Then the path "workingHoursDay" reads "6"
+
+  Scenario: 4) Type errors and unknown paths
+    Given an in-memory demo config
+    Then setting the path "author.age" to "thirty" fails
+    And setting the path "author.birthday" to "28 Feb 1990" fails
+    And setting the path "author.nickname" to "JJ" fails
+    And the path "author.age" reads "25"
+
+  Scenario: 5) Numeric overflows and truncations
+    Given an in-memory server inventory
+    Then setting the path "regions.asia.weight" to the number 300 fails
+    And setting the path "regions.asia.slots" to the number -1 fails
+    And setting the path "regions.asia.slots" to "300" fails
+    And setting the path "regions.asia.capacity" to the number 3.9 fails
+    And the path "regions.asia.weight" reads "5"
+    And the path "regions.asia.slots" reads "4"
+    And the path "regions.asia.capacity" reads "12"
+    When I set the path "regions.asia.capacity" to the number 16.0
+    And I set the path "regions.asia.weight" to the number 127
+    Then the path "regions.asia.capacity" reads "16"
+    And the path "regions.asia.weight" reads "127"
+
+  Scenario: 6) Set on a Struct value
+    Given an in-memory demo config held by value
+    Then setting the path "version" to "2" fails
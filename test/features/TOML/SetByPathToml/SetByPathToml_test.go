@@ -0,0 +1,133 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the runtime set by dotted path
+package SetByPathToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configObject interface{}
+
+func anInMemoryDemoConfig() error {
+	config := TOML2.DemoTOMLConfig{}
+	config.Author.FirstName = "Jason"
+	config.Author.Age = 25
+	config.Hobbies = []string{ "badminton", "reading" }
+	configObject = &config
+	return nil
+}
+
+func anInMemoryDemoConfigHeldByValue() error {
+	configObject = TOML2.DemoTOMLConfig{ Version: "1" }
+	return nil
+}
+
+func anInMemoryServerInventory() error {
+	configObject = &TOML2.ServerInventory{
+		Name: "inventory",
+		Labels: map[string]string{ "team": "ops" },
+		Regions: map[string]interface{}{
+			"asia": map[string]interface{}{ "capacity": 12, "weight": int8(5), "slots": uint8(4) },
+		},
+	}
+	return nil
+}
+
+func iSetThePathTo(path, value string) error {
+	return TOML.Set(configObject, path, value)
+}
+
+func iSetThePathToTheInteger(path string, value int) error {
+	return TOML.Set(configObject, path, value)
+}
+
+// the number as a float64 (with a ".") or an int
+func parseNumber(value string) interface{} {
+	if strings.Contains(value, ".") {
+		fValue, _ := strconv.ParseFloat(value, 64)
+		return fValue
+	}
+	iValue, _ := strconv.Atoi(value)
+	return iValue
+}
+
+func iSetThePathToTheNumber(path, value string) error {
+	return TOML.Set(configObject, path, parseNumber(value))
+}
+
+func settingThePathToTheNumberFails(path, value string) error {
+	return checkPathError(TOML.Set(configObject, path, parseNumber(value)), path, value)
+}
+
+// the error must be a *common.PathError
+func checkPathError(err error, path, value string) error {
+	var pathErr *common.PathError
+	if !errors.As(err, &pathErr) {
+		return fmt.Errorf("expected a PathError on setting the path [%v] to [%v]; got [%v]", path, value, err)
+	}
+	return nil
+}
+
+func thePathReads(path, expected string) error {
+	value, err := TOML.Get[interface{}](configObject, path)
+	if err != nil {
+		return err
+	}
+	if actual := fmt.Sprintf("%v", value); strings.Compare(actual, expected) != 0 {
+		return fmt.Errorf("expected [%v] for the path [%v]; got [%v]", expected, path, actual)
+	}
+	return nil
+}
+
+func theValueOfThePathIsAnInt(path string) error {
+	value, err := TOML.Get[interface{}](configObject, path)
+	if err != nil {
+		return err
+	}
+	if _, ok := value.(int); !ok {
+		return fmt.Errorf("expected an int for the path [%v]; got [%T]", path, value)
+	}
+	return nil
+}
+
+func settingThePathToFails(path, value string) error {
+	return checkPathError(TOML.Set(configObject, path, value), path, value)
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^an in-memory demo config$`, anInMemoryDemoConfig)
+	s.Step(`^an in-memory demo config held by value$`, anInMemoryDemoConfigHeldByValue)
+	s.Step(`^an in-memory server inventory$`, anInMemoryServerInventory)
+	s.Step(`^I set the path "([^"]*)" to "([^"]*)"$`, iSetThePathTo)
+	s.Step(`^I set the path "([^"]*)" to the integer (\d+)$`, iSetThePathToTheInteger)
+	s.Step(`^I set the path "([^"]*)" to the number (-?[\d.]+)$`, iSetThePathToTheNumber)
+	s.Step(`^setting the path "([^"]*)" to the number (-?[\d.]+) fails$`, settingThePathToTheNumberFails)
+	s.Step(`^the path "([^"]*)" reads "([^"]*)"$`, thePathReads)
+	s.Step(`^the value of the path "([^"]*)" is an int$`, theValueOfThePathIsAnInt)
+	s.Step(`^setting the path "([^"]*)" to "([^"]*)" fails$`, settingThePathToFails)
+}
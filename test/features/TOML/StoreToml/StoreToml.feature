@@ -0,0 +1,46 @@
+Feature: TOML thread-safe config Store
+  TOML.Store[T] loads the config file into a fresh value, validates it and
+  swaps the snapshot pointer atomically; Get is lock-free and readers never
+  see a half populated value. A failed reload keeps the current snapshot.
+
+  Assumptions for the feature test:
+  - the config file is copied into a temp folder and replaced between loads
+  - the ServerConfig Struct (with validation rules) is used
+
+  Major use cases:
+  - load and read the snapshot
+  - concurrent readers during reloads always see a complete snapshot
+  - invalid contents or a failing Validate function keep the current snapshot
+  - publish a modified copy with Swap
+
+  Scenario: 1) Load the snapshot
+    Given a Store of the config file "storeToml.toml"
+    Then the snapshot is empty
+    When the Store is loaded
+    Then the snapshot has the name "order-service" and the port 8080
+
+  Scenario: 2) Concurrent readers during reloads
+    Given a Store of the config file "storeToml.toml"
+    When the Store is loaded
+    And the config file is reloaded 50 times alternating with "storeTomlUpdated.toml" while 8 goroutines read
+    Then every snapshot read is complete
+
+  Scenario: 3) Failed reloads keep the current snapshot
+    Given a Store of the config file "storeToml.toml"
+    When the Store is loaded
+    And the config file is replaced by "storeTomlInvalid.toml"
+    Then loading the Store fails
+    And the snapshot has the name "order-service" and the port 8080
+    When the config file is replaced by "storeTomlUpdated.toml"
+    And the Store validation rejects the port 9090
+    Then loading the Store fails
+    And the snapshot has the name "order-service" and the port 8080
+
+  Scenario: 4) Swap a modified copy
+    Given a Store of the config file "storeToml.toml"
+    When the Store is loaded
+    Then swapping in a copy with the port 70000 fails
+    And the snapshot has the name "order-service" and the port 8080
+    When a copy with the port 8443 is swapped in
+    Then the previous snapshot has the port 8080
+    And the snapshot has the name "order-service" and the port 8443
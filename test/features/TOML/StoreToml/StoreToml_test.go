@@ -0,0 +1,196 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the thread-safe config Store
+package StoreToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var store *TOML.Store[TOML2.ServerConfig]
+var configFile string
+var previous *TOML2.ServerConfig
+var inconsistentReads []string
+
+// copy the fixture into the config file (temp folder)
+func replaceConfigFile(fixture string) error {
+	bBytes, err := common.LoadFile(fixture)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFile, bBytes, 0644)
+}
+
+func aStoreOfTheConfigFile(fixture string) error {
+	dir, err := os.MkdirTemp("", "storeToml")
+	if err != nil {
+		return err
+	}
+	configFile = filepath.Join(dir, "config.toml")
+	if err := replaceConfigFile(fixture); err != nil {
+		return err
+	}
+	store = TOML.NewStore[TOML2.ServerConfig](configFile)
+	inconsistentReads = []string{}
+	return nil
+}
+
+func theSnapshotIsEmpty() error {
+	if snapshot := store.Get(); snapshot != nil {
+		return fmt.Errorf("expected an empty snapshot; got [%v]", snapshot.Name)
+	}
+	return nil
+}
+
+func theStoreIsLoaded() error {
+	_, err := store.Load()
+	return err
+}
+
+func loadingTheStoreFails() error {
+	if _, err := store.Load(); err == nil {
+		return fmt.Errorf("expected an error on loading the Store")
+	}
+	return nil
+}
+
+func theSnapshotHasTheNameAndThePort(name string, port int) error {
+	snapshot := store.Get()
+	if snapshot == nil {
+		return fmt.Errorf("expected a snapshot; got nil")
+	}
+	if snapshot.Name != name || snapshot.Port != port {
+		return fmt.Errorf("expected [%v:%v]; got [%v:%v]", name, port, snapshot.Name, snapshot.Port)
+	}
+	return nil
+}
+
+// every snapshot must be either of the 2 config files (never a mix)
+func checkSnapshot(snapshot *TOML2.ServerConfig) {
+	switch {
+	case snapshot.Name == "order-service" && snapshot.Port == 8080 && snapshot.Limits.MaxWorkers == 16 && snapshot.TLS.Enabled:
+	case snapshot.Name == "billing-service" && snapshot.Port == 9090 && snapshot.Limits.MaxWorkers == 8 && !snapshot.TLS.Enabled:
+	default:
+		inconsistentReads = append(inconsistentReads, fmt.Sprintf("%v:%v", snapshot.Name, snapshot.Port))
+	}
+}
+
+func theConfigFileIsReloadedTimesAlternatingWithWhileGoroutinesRead(times int, fixture string, readers int) error {
+	fixtures := []string{ "storeToml.toml", fixture }
+	done := make(chan struct{})
+	lock := sync.Mutex{}
+	group := sync.WaitGroup{}
+
+	for i := 0; i < readers; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snapshot := store.Get()
+				lock.Lock()
+				checkSnapshot(snapshot)
+				lock.Unlock()
+			}
+		}()
+	}	// end -- for (readers)
+
+	var err error
+	for i := 1; i <= times && err == nil; i++ {
+		if err = replaceConfigFile(fixtures[i%2]); err == nil {
+			_, err = store.Load()
+		}
+	}	// end -- for (reloads)
+	close(done)
+	group.Wait()
+	return err
+}
+
+func everySnapshotReadIsComplete() error {
+	if len(inconsistentReads) > 0 {
+		return fmt.Errorf("inconsistent snapshots read %v", inconsistentReads)
+	}
+	return nil
+}
+
+func theConfigFileIsReplacedBy(fixture string) error {
+	return replaceConfigFile(fixture)
+}
+
+func theStoreValidationRejectsThePort(port int) error {
+	store.Validate = func(config *TOML2.ServerConfig) error {
+		if config.Port == port {
+			return fmt.Errorf("the port %v is reserved", port)
+		}
+		return nil
+	}
+	return nil
+}
+
+// shallow copy of the snapshot (the snapshot itself must not be modified)
+func copySnapshot(port int) *TOML2.ServerConfig {
+	config := *store.Get()
+	config.Port = port
+	return &config
+}
+
+func swappingInACopyWithThePortFails(port int) error {
+	if _, err := store.Swap(copySnapshot(port)); err == nil {
+		return fmt.Errorf("expected an error on swapping in the port %v", port)
+	}
+	return nil
+}
+
+func aCopyWithThePortIsSwappedIn(port int) (err error) {
+	previous, err = store.Swap(copySnapshot(port))
+	return err
+}
+
+func thePreviousSnapshotHasThePort(port int) error {
+	if previous == nil || previous.Port != port {
+		return fmt.Errorf("expected the previous snapshot with the port %v; got [%v]", port, previous)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^a Store of the config file "([^"]*)"$`, aStoreOfTheConfigFile)
+	s.Step(`^the snapshot is empty$`, theSnapshotIsEmpty)
+	s.Step(`^the Store is loaded$`, theStoreIsLoaded)
+	s.Step(`^loading the Store fails$`, loadingTheStoreFails)
+	s.Step(`^the snapshot has the name "([^"]*)" and the port (\d+)$`, theSnapshotHasTheNameAndThePort)
+	s.Step(`^the config file is reloaded (\d+) times alternating with "([^"]*)" while (\d+) goroutines read$`, theConfigFileIsReloadedTimesAlternatingWithWhileGoroutinesRead)
+	s.Step(`^every snapshot read is complete$`, everySnapshotReadIsComplete)
+	s.Step(`^the config file is replaced by "([^"]*)"$`, theConfigFileIsReplacedBy)
+	s.Step(`^the Store validation rejects the port (\d+)$`, theStoreValidationRejectsThePort)
+	s.Step(`^swapping in a copy with the port (\d+) fails$`, swappingInACopyWithThePortFails)
+	s.Step(`^a copy with the port (\d+) is swapped in$`, aCopyWithThePortIsSwappedIn)
+	s.Step(`^the previous snapshot has the port (\d+)$`, thePreviousSnapshotHasThePort)
+}
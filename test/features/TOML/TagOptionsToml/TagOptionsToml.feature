@@ -0,0 +1,46 @@
+Feature: TOML Tag options
+  Struct fields could carry encoding/json alike options on the toml Tag;
+  toml:"name,omitempty" omits empty values on save, toml:"-" skips the
+  field entirely and toml:",inline" treats the child Struct's fields as
+  the parent's fields.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - the loader, the saver and the getters apply the same Tag options
+  - fields without omitempty are always saved (even zero values)
+  - child Structs of parent fields without a toml Tag are saved as well
+
+  Scenario: 1) Load with Tag options
+    Given there is a TOML in the current folder named "tagOptionsToml.toml"
+    When I load the TOML file named "tagOptionsToml.toml"
+    Then the value for field "host" is "db.example.com"
+    And the value for field "maxOpen" is "20"
+    And the value for field "maxIdle" is "5"
+    And the value for field "connected" is "false"
+    And the key "connected" is undecoded
+
+  Scenario: 2) Save with Tag options
+    Given there is a TOML in the current folder named "tagOptionsToml.toml"
+    When I load the TOML file named "tagOptionsToml.toml"
+    And clear the optional fields and mark the connection as connected
+    And save changes to the "tagOptionsToml_test.toml"
+    Then the file "tagOptionsToml_test.toml" contains the keys "host,port,maxOpen"
+    And the file "tagOptionsToml_test.toml" does not contain the keys "password,replicas,retryCount,maxIdle,connected"
+    And finally reload the configuration file "tagOptionsToml_test.toml", "maxOpen" should equals to "20"
+
+  Scenario: 3) Getters with Tag options
+    Given there is a TOML in the current folder named "tagOptionsToml.toml"
+    When I load the TOML file named "tagOptionsToml.toml"
+    Then the getter for key "maxOpen" yields "20"
+    And the getter for key "password" yields "s3cret"
+    And the getter for key "connected" is not found
+
+  Scenario: 4) Round trip a parent field without a toml Tag
+    Given there is a TOML in the current folder named "replicaToml.toml"
+    When I load the replica TOML file named "replicaToml.toml"
+    And save the replica changes to the "replicaToml_test.toml"
+    Then the file "replicaToml_test.toml" contains the keys "name,primary.host,primary.port"
+    And finally reload the replica file "replicaToml_test.toml", the primary should be "db.example.com:5432"
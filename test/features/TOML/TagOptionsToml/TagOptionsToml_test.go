@@ -0,0 +1,222 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on the toml Tag options (omitempty, "-" and inline)
+package TagOptionsToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.DatabaseConfig
+var metaData common.DecodeMetaData
+var replicaObject TOML2.ReplicaConfig
+
+func gotTomlFileName(tomlFile string) error {
+	if len(tomlFile)>0 {
+		configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.DatabaseConfig{}))
+		return nil
+	}
+	return fmt.Errorf("the given 'name' is not Valid (%v)", tomlFile)
+}
+
+func loadTomlFile(_ string) error {
+	var err error
+	configObject = TOML2.DatabaseConfig{}
+
+	_, metaData, err = configReader.LoadWithMetaData(&configObject)
+	if err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func getFieldValue(object TOML2.DatabaseConfig, field string) string {
+	switch field {
+	case "host":
+		return object.Host
+	case "maxOpen":
+		return strconv.Itoa(object.Pool.MaxOpen)
+	case "maxIdle":
+		return strconv.Itoa(object.Pool.MaxIdle)
+	case "connected":
+		return strconv.FormatBool(object.Connected)
+	}
+	return fmt.Sprintf("unsupported field [%v]", field)
+}
+
+func theValueForFieldIs(field, value string) error {
+	if actual := getFieldValue(configObject, field); strings.Compare(actual, value) != 0 {
+		return fmt.Errorf("for field '%v', expected '%v' but got '%v'", field, value, actual)
+	}
+	return nil
+}
+
+func theKeyIsUndecoded(key string) error {
+	if !metaData.IsUndecoded(key) {
+		return fmt.Errorf("the key [%v] should be undecoded; undecoded keys => %v", key, metaData.UndecodedKeys())
+	}
+	return nil
+}
+
+func clearTheOptionalFields() error {
+	configObject.Password = ""
+	configObject.RetryCount = 0
+	configObject.Pool.MaxIdle = 0
+	configObject.Connected = true
+	return nil
+}
+
+func saveChangesToToml(tomlFile string) error {
+	err := configReader.Save(tomlFile, configObject)
+	if err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+// return the keys declared in the given toml file
+func getKeysInFile(filename string) (map[string]bool, error) {
+	bContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	for _, line := range strings.Split(string(bContent), "\n") {
+		if idx := strings.Index(line, "="); idx > 0 {
+			keys[strings.TrimSpace(line[:idx])] = true
+		}
+	}
+	return keys, nil
+}
+
+func theFileContainsTheKeys(filename, keys string) error {
+	keysInFile, err := getKeysInFile(filename)
+	if err != nil {
+		return err
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if !keysInFile[key] {
+			return fmt.Errorf("the key [%v] should be saved to [%v]", key, filename)
+		}
+	}
+	return nil
+}
+
+func theFileDoesNotContainTheKeys(filename, keys string) error {
+	keysInFile, err := getKeysInFile(filename)
+	if err != nil {
+		return err
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if keysInFile[key] {
+			return fmt.Errorf("the key [%v] should NOT be saved to [%v]", key, filename)
+		}
+	}
+	return nil
+}
+
+func reconciliationOnFieldsSet(filename, field, value string) error {
+	configReader.Name = filename
+	configObject2 := TOML2.DatabaseConfig{}
+
+	if _, err := configReader.Load(&configObject2); err != nil {
+		return fmt.Errorf("something wrong when loading the config file %v => %v\n", filename, err)
+	}
+	if actual := getFieldValue(configObject2, field); strings.Compare(actual, value) != 0 {
+		return fmt.Errorf("expected value to be [%v] BUT have [%v]\n", value, actual)
+	}
+	return nil
+}
+
+func theGetterForKeyYields(key, value string) error {
+	if ok, iVal := configReader.GetIntValueByKey(configObject, key); ok {
+		if strings.Compare(strconv.FormatInt(iVal, 10), value) != 0 {
+			return fmt.Errorf("for key '%v', expected '%v' but got '%v'", key, value, iVal)
+		}
+		return nil
+	}
+	if !configReader.IsFieldStringValueMatched(configObject, key, value) {
+		return fmt.Errorf("for key '%v', expected '%v'", key, value)
+	}
+	return nil
+}
+
+func theGetterForKeyIsNotFound(key string) error {
+	if ok, _ := configReader.GetBoolValueByKey(configObject, key); ok {
+		return fmt.Errorf("the key [%v] should NOT be found", key)
+	}
+	return nil
+}
+
+func loadReplicaTomlFile(tomlFile string) error {
+	replicaObject = TOML2.ReplicaConfig{}
+	reader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(replicaObject))
+	if _, err := reader.Load(&replicaObject); err != nil {
+		return fmt.Errorf("Error in loading the TOML file. %v\n", err)
+	}
+	return nil
+}
+
+func saveReplicaChangesToToml(tomlFile string) error {
+	reader := TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(replicaObject))
+	if err := reader.Save(tomlFile, replicaObject); err != nil {
+		return fmt.Errorf("could NOT save the config object => %v", err)
+	}
+	return nil
+}
+
+func reconciliationOnReplicaPrimary(filename, primary string) error {
+	replicaObject2 := TOML2.ReplicaConfig{}
+	reader := TOML.NewTOMLConfigImpl(filename, reflect.TypeOf(replicaObject2))
+
+	if _, err := reader.Load(&replicaObject2); err != nil {
+		return fmt.Errorf("something wrong when loading the config file %v => %v\n", filename, err)
+	}
+	actual := fmt.Sprintf("%v:%v", replicaObject2.Primary.Host, replicaObject2.Primary.Port)
+	if strings.Compare(actual, primary) != 0 {
+		return fmt.Errorf("expected the primary to be [%v] BUT have [%v]\n", primary, actual)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, gotTomlFileName)
+	s.Step(`^I load the TOML file named "([^"]*)"$`, loadTomlFile)
+	s.Step(`^the value for field "([^"]*)" is "([^"]*)"$`, theValueForFieldIs)
+	s.Step(`^the key "([^"]*)" is undecoded$`, theKeyIsUndecoded)
+	s.Step(`^clear the optional fields and mark the connection as connected$`, clearTheOptionalFields)
+	s.Step(`^save changes to the "([^"]*)"$`, saveChangesToToml)
+	s.Step(`^the file "([^"]*)" contains the keys "([^"]*)"$`, theFileContainsTheKeys)
+	s.Step(`^the file "([^"]*)" does not contain the keys "([^"]*)"$`, theFileDoesNotContainTheKeys)
+	s.Step(`^finally reload the configuration file "([^"]*)", "([^"]*)" should equals to "([^"]*)"$`, reconciliationOnFieldsSet)
+	s.Step(`^I load the replica TOML file named "([^"]*)"$`, loadReplicaTomlFile)
+	s.Step(`^save the replica changes to the "([^"]*)"$`, saveReplicaChangesToToml)
+	s.Step(`^finally reload the replica file "([^"]*)", the primary should be "([^"]*)"$`, reconciliationOnReplicaPrimary)
+	s.Step(`^the getter for key "([^"]*)" yields "([^"]*)"$`, theGetterForKeyYields)
+	s.Step(`^the getter for key "([^"]*)" is not found$`, theGetterForKeyIsNotFound)
+}
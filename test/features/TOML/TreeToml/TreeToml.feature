@@ -0,0 +1,44 @@
+Feature: TOML untyped document tree
+  TOML.ParseFile returns a Tree to inspect and modify toml contents without
+  a Struct; keys, tables, the TOML type and the position of every node are
+  available. ToStruct / FromStruct bridge the Tree to the reflection
+  decoder / encoder.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - list the keys, walk the tables and read the TOML types
+  - set and delete entries (tables are created as needed)
+  - convert between a Tree and a Struct
+
+  Scenario: 1) Inspect the keys and types
+    Given the TOML file "treeToml.toml" is parsed into a Tree
+    Then the keys are "name,port,ratio,enabled,released,tags,limits.maxWorkers,tls.enabled,tls.certFile,tls.client.verify"
+    And the walked tables are "limits,tls,tls.client"
+    And the key "port" is an "integer" at "3:1"
+    And the key "ratio" is an "float" at "4:1"
+    And the key "released" is an "datetime" at "6:1"
+    And the key "tags" is an "array" at "7:1"
+    And the key "tls" is an "table" at "10:1"
+    And the key "tls.enabled" is an "bool" at "11:1"
+
+  Scenario: 2) Set and delete entries
+    Given the TOML file "treeToml.toml" is parsed into a Tree
+    When I set the key "tls.client.timeout" to the integer 30
+    And I set the key "owner.team" to the string "ops"
+    And I set the key "name" to the string "billing-service"
+    And I delete the key "limits"
+    And I delete the key "tls.client"
+    Then the Tree equals to the file "treeTomlEditedExpected.toml"
+    And setting the key "name.first" fails
+
+  Scenario: 3) Bridge to Structs
+    Given the TOML file "treeTomlServer.toml" is parsed into a Tree
+    When I convert the Tree to a server config
+    Then the server config name is "order-service"
+    And a Tree from the server config has the integer 16 at "limits.maxWorkers"
+
+  Scenario: 4) Invalid contents
+    Then parsing the TOML file "treeTomlDuplicate.toml" fails with "3: key [name] already defined at line 1"
@@ -0,0 +1,155 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+
+// testing on the untyped document tree
+package TreeToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var tree *TOML.Tree
+var configObject TOML2.ServerConfig
+
+func theTomlFileIsParsedIntoATree(tomlFile string) error {
+	var err error
+	tree, err = TOML.ParseFile(tomlFile)
+	return err
+}
+
+func theKeysAre(keys string) error {
+	if actual := strings.Join(tree.Keys(), ","); strings.Compare(actual, keys) != 0 {
+		return fmt.Errorf("expected keys [%v]; got [%v]", keys, actual)
+	}
+	return nil
+}
+
+func theWalkedTablesAre(tables string) error {
+	walked := []string{}
+	err := tree.Walk(func(node *TOML.Node) error {
+		if node.Type == TOML.NodeTable {
+			walked = append(walked, node.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if actual := strings.Join(walked, ","); strings.Compare(actual, tables) != 0 {
+		return fmt.Errorf("expected tables [%v]; got [%v]", tables, actual)
+	}
+	return nil
+}
+
+func theKeyIsAnAt(key, nodeType, position string) error {
+	node := tree.GetNode(key)
+	if node == nil {
+		return fmt.Errorf("key [%v] not found", key)
+	}
+	if strings.Compare(node.Type.String(), nodeType) != 0 || strings.Compare(node.Position.String(), position) != 0 {
+		return fmt.Errorf("expected [%v] at [%v] for key [%v]; got [%v] at [%v]", nodeType, position, key, node.Type, node.Position)
+	}
+	return nil
+}
+
+func iSetTheKeyToTheInteger(key string, value int) error {
+	return tree.Set(key, value)
+}
+
+func iSetTheKeyToTheString(key, value string) error {
+	return tree.Set(key, value)
+}
+
+func iDeleteTheKey(key string) error {
+	if !tree.Delete(key) {
+		return fmt.Errorf("key [%v] not found", key)
+	}
+	return nil
+}
+
+func theTreeEqualsToTheFile(expectedFilename string) error {
+	bExpected, err := ioutil.ReadFile(expectedFilename)
+	if err != nil {
+		return err
+	}
+	if bContent := tree.Bytes(); !bytes.Equal(bContent, bExpected) {
+		return fmt.Errorf("the Tree differs from [%v]; got =>\n%v", expectedFilename, string(bContent))
+	}
+	return nil
+}
+
+func settingTheKeyFails(key string) error {
+	if err := tree.Set(key, "value"); err == nil {
+		return fmt.Errorf("expected an error on setting the key [%v]", key)
+	}
+	return nil
+}
+
+func iConvertTheTreeToAServerConfig() error {
+	configObject = TOML2.ServerConfig{}
+	return tree.ToStruct(&configObject)
+}
+
+func theServerConfigNameIs(name string) error {
+	if strings.Compare(configObject.Name, name) != 0 {
+		return fmt.Errorf("expected name [%v]; got [%v]", name, configObject.Name)
+	}
+	return nil
+}
+
+func aTreeFromTheServerConfigHasTheIntegerAt(value int, key string) error {
+	configTree, err := TOML.FromStruct(configObject)
+	if err != nil {
+		return err
+	}
+	if actual, ok := configTree.Get(key).(int64); !ok || actual != int64(value) {
+		return fmt.Errorf("expected the integer [%v] at [%v]; got [%v]", value, key, configTree.Get(key))
+	}
+	return nil
+}
+
+func parsingTheTomlFileFailsWith(tomlFile, message string) error {
+	_, err := TOML.ParseFile(tomlFile)
+	if err == nil || !strings.HasSuffix(err.Error(), message) {
+		return fmt.Errorf("expected an error ending with [%v]; got [%v]", message, err)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.Step(`^the TOML file "([^"]*)" is parsed into a Tree$`, theTomlFileIsParsedIntoATree)
+	s.Step(`^the keys are "([^"]*)"$`, theKeysAre)
+	s.Step(`^the walked tables are "([^"]*)"$`, theWalkedTablesAre)
+	s.Step(`^the key "([^"]*)" is an "([^"]*)" at "([^"]*)"$`, theKeyIsAnAt)
+	s.Step(`^I set the key "([^"]*)" to the integer (\d+)$`, iSetTheKeyToTheInteger)
+	s.Step(`^I set the key "([^"]*)" to the string "([^"]*)"$`, iSetTheKeyToTheString)
+	s.Step(`^I delete the key "([^"]*)"$`, iDeleteTheKey)
+	s.Step(`^the Tree equals to the file "([^"]*)"$`, theTreeEqualsToTheFile)
+	s.Step(`^setting the key "([^"]*)" fails$`, settingTheKeyFails)
+	s.Step(`^I convert the Tree to a server config$`, iConvertTheTreeToAServerConfig)
+	s.Step(`^the server config name is "([^"]*)"$`, theServerConfigNameIs)
+	s.Step(`^a Tree from the server config has the integer (\d+) at "([^"]*)"$`, aTreeFromTheServerConfigHasTheIntegerAt)
+	s.Step(`^parsing the TOML file "([^"]*)" fails with "([^"]*)"$`, parsingTheTomlFileFailsWith)
+}
@@ -83,7 +83,7 @@ func setValueForField(field, value string) error {
 }
 
 func saveChangesToToml(tomlFile string) error {
-	err := configReader.Save(tomlFile, reflect.TypeOf(configObject), configObject)
+	err := configReader.Save(tomlFile, configObject)
 	if err != nil {
 		return fmt.Errorf("could NOT save the config object => %v to file resource '%v'", configObject, tomlFile)
 	}
@@ -160,7 +160,7 @@ func setupScenario2() error {
 
 func persistConfigValuesToToml(filename string) error {
 	if !common.IsStringEmptyOrNil(filename) {
-		err := configReader.Save(filename, reflect.TypeOf(configObject), configObject)
+		err := configReader.Save(filename, configObject)
 		if err != nil {
 			return fmt.Errorf("somethng wrong when persisting the toml file~ %v\n", err)
 		}
@@ -581,7 +581,7 @@ And multi child array-field "client.address.geopoint.latLonArr" should yield "37
  */
 
 func persistMultiStructToToml(filename string) error {
-	err := configReader.Save(filename, reflect.TypeOf(transObject), transObject)
+	err := configReader.Save(filename, transObject)
 	if err != nil {
 		return err
 	}
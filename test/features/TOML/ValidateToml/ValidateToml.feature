@@ -0,0 +1,46 @@
+Feature: TOML field level validation
+  values loaded from a configuration file should be validated against the
+  rules declared through the "validate" Tag of the targeted Struct;
+  bad values should never slip through silently.
+
+  Assumptions for the feature test:
+  - data file is in TOML format
+  - data file is in the current folder next to the feature file
+
+  Major use cases:
+  - a valid config file loads without errors
+  - an invalid config file reports every failure with the toml key and line
+  - cross-field rules (required_if, lte_field) and the Struct level
+    Validate() hook are checked after loading and before saving
+
+  Scenario: 1) Load a TOML with valid values
+    Given there is a TOML in the current folder named "validateToml.toml"
+    When I load the TOML file named "validateToml.toml"
+    Then no validation error is reported
+    And the value for field "name" is "order-service"
+
+  Scenario: 2) Load a TOML with invalid values
+    Given there is a TOML in the current folder named "validateTomlInvalid.toml"
+    When I load the TOML file named "validateTomlInvalid.toml"
+    Then 6 validation failures are reported
+    And the validation failure for key "name" at line 1 contains "does not match the pattern"
+    And the validation failure for key "port" at line 2 contains "greater than the maximum 65535"
+    And the validation failure for key "role" at line 3 contains "is not one of [admin user]"
+    And the validation failure for key "tags" at line 4 contains "length 4 is greater than the maximum 3"
+    And the validation failure for key "limits.maxWorkers" at line 7 contains "less than the minimum 1"
+    And the validation failure for key "limits.minWorkers" at line 9 contains "value 1 is not <= [limits.maxWorkers] (0)"
+
+  Scenario: 3) Load a TOML violating the cross-field rules
+    Given there is a TOML in the current folder named "validateTomlCrossField.toml"
+    When I load the TOML file named "validateTomlCrossField.toml"
+    Then 2 validation failures are reported
+    And the validation failure for key "limits.minWorkers" at line 6 contains "value 32 is not <= [limits.maxWorkers] (16)"
+    And the validation failure for key "tls" contains "the cert file and key file should be different"
+
+  Scenario: 4) Struct level Validate() hook before saving
+    Given there is a TOML in the current folder named "validateToml.toml"
+    When I load the TOML file named "validateToml.toml"
+    And disable the tls setup
+    Then saving to "validateToml_test.toml" reports 1 validation failures
+    And the validation failure for key "TOML.ServerConfig" contains "admin servers must enable tls"
+    And the file "validateToml_test.toml" is not created
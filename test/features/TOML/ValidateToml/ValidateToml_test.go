@@ -0,0 +1,157 @@
+/*
+ *  Copyright Project - CFactor, Author - quoeamaster, (C) 2018
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// testing on validating toml values through the "validate" Tag
+package ValidateToml
+
+import (
+	"github.com/DATA-DOG/godog"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/quoeamaster/CFactor/TOML"
+	"github.com/quoeamaster/CFactor/common"
+	TOML2 "github.com/quoeamaster/CFactor/test/features/TOML"
+)
+
+var configReader TOML.TOMLConfigImpl
+var configObject TOML2.ServerConfig
+var loadErr error
+
+func gotTomlFileName(tomlFile string) error {
+	if len(tomlFile)>0 {
+		configReader = TOML.NewTOMLConfigImpl(tomlFile, reflect.TypeOf(TOML2.ServerConfig{}))
+		return nil
+	}
+	return fmt.Errorf("the given 'name' is not Valid (%v)", tomlFile)
+}
+
+func loadTomlFile(_ string) error {
+	configObject = TOML2.ServerConfig{}
+	_, loadErr = configReader.Load(&configObject)
+
+	return nil
+}
+
+func noValidationErrorIsReported() error {
+	if loadErr != nil {
+		return fmt.Errorf("expected no error BUT got => %v", loadErr)
+	}
+	return nil
+}
+
+func theValueForFieldIs(field, value string) error {
+	if strings.Compare(field, "name")==0 && strings.Compare(configObject.Name, value)==0 {
+		return nil
+	}
+	return fmt.Errorf("for field '%v', expected '%v' but got '%v'", field, value, configObject.Name)
+}
+
+func getValidationError() (*common.ValidationError, error) {
+	vErr, ok := loadErr.(*common.ValidationError)
+	if !ok {
+		return nil, fmt.Errorf("expected a validation error BUT got => %v", loadErr)
+	}
+	return vErr, nil
+}
+
+func validationFailuresAreReported(count int) error {
+	vErr, err := getValidationError()
+	if err != nil {
+		return err
+	}
+	if len(vErr.Failures) != count {
+		return fmt.Errorf("expected %v failures BUT got %v => %v", count, len(vErr.Failures), vErr)
+	}
+	return nil
+}
+
+func theValidationFailureForKeyContains(key, message string) error {
+	vErr, err := getValidationError()
+	if err != nil {
+		return err
+	}
+	for _, failure := range vErr.Failures {
+		if strings.Compare(failure.Key, key) == 0 && strings.Contains(failure.Message, message) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no failure found for key [%v] containing {%v} => %v", key, message, vErr)
+}
+
+func theValidationFailureForKeyAtLineContains(key string, line int, message string) error {
+	vErr, err := getValidationError()
+	if err != nil {
+		return err
+	}
+	for _, failure := range vErr.Failures {
+		if strings.Compare(failure.Key, key) != 0 {
+			continue
+		}
+		if failure.Line != line {
+			return fmt.Errorf("expected failure of [%v] at line %v BUT got line %v", key, line, failure.Line)
+		}
+		if !strings.Contains(failure.Message, message) {
+			return fmt.Errorf("expected failure of [%v] to contain {%v} BUT got {%v}", key, message, failure.Message)
+		}
+		return nil
+	}
+	return fmt.Errorf("no failure found for key [%v] => %v", key, vErr)
+}
+
+/* ------------------------------------------------------------ */
+/*	scenario 4) Struct level Validate() hook before saving		*/
+/* ------------------------------------------------------------ */
+
+func disableTheTlsSetup() error {
+	configObject.TLS = TOML2.ServerTLS{ Enabled: false }
+	return nil
+}
+
+func savingToReportsValidationFailures(tomlFile string, count int) error {
+	loadErr = configReader.Save(tomlFile, configObject)
+	return validationFailuresAreReported(count)
+}
+
+func theFileIsNotCreated(tomlFile string) error {
+	if _, err := os.Stat(tomlFile); !os.IsNotExist(err) {
+		os.Remove(tomlFile)
+		return fmt.Errorf("the file [%v] should NOT be created", tomlFile)
+	}
+	return nil
+}
+
+// testing the features of this BDD story use case.
+func FeatureContext(s *godog.Suite) {
+	s.BeforeScenario(func(i interface{}) {
+		loadErr = nil
+	})
+
+	s.Step(`^there is a TOML in the current folder named "([^"]*)"$`, gotTomlFileName)
+	s.Step(`^I load the TOML file named "([^"]*)"$`, loadTomlFile)
+	s.Step(`^no validation error is reported$`, noValidationErrorIsReported)
+	s.Step(`^the value for field "([^"]*)" is "([^"]*)"$`, theValueForFieldIs)
+	s.Step(`^(\d+) validation failures are reported$`, validationFailuresAreReported)
+	s.Step(`^the validation failure for key "([^"]*)" at line (\d+) contains "([^"]*)"$`, theValidationFailureForKeyAtLineContains)
+	s.Step(`^the validation failure for key "([^"]*)" contains "([^"]*)"$`, theValidationFailureForKeyContains)
+
+	// scenario 4
+	s.Step(`^disable the tls setup$`, disableTheTlsSetup)
+	s.Step(`^saving to "([^"]*)" reports (\d+) validation failures$`, savingToReportsValidationFailures)
+	s.Step(`^the file "([^"]*)" is not created$`, theFileIsNotCreated)
+}